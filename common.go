@@ -3,15 +3,39 @@ package gotree
 import (
 	"errors"
 	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
 )
 
 var (
 	ErrNilTree  = errors.New("tree is nil")
 	ErrNotFound = errors.New("No item found")
+	ErrCycle    = errors.New("cycle detected")
+	ErrType     = errors.New("value has a different type")
 )
 
+// PathError records an error and the FullKey of the node where it happened.
+type PathError struct {
+	FullKey string
+	Err     error
+}
+
+func (e *PathError) Error() string {
+	if e.FullKey == "" {
+		return e.Err.Error()
+	}
+	return e.FullKey + ": " + e.Err.Error()
+}
+
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
 // FilterFunc defines a function type that takes a Node and returns a boolean
-// value indicating whether the node satisfies certain conditions.
+// value indicating whether the node satisfies certain conditions. Find stops
+// at the first node it accepts, while Traverse collects every accepted node
+// without descending into it; use Visit for finer control over the walk.
 type FilterFunc func(Node) bool
 
 func NoneFilter(_ Node) bool {
@@ -33,6 +57,118 @@ func FullKeyFilter(fullkey string) FilterFunc {
 	}
 }
 
+// KeyFoldFilter returns a FilterFunc that checks if Node.Key equals key under
+// Unicode case-folding, so "ID", "Id" and "id" are all matched by "id".
+func KeyFoldFilter(key string) FilterFunc {
+	return func(n Node) bool {
+		return strings.EqualFold(n.Key, key)
+	}
+}
+
+// KeyPrefixFilter returns a FilterFunc that checks if Node.Key starts with
+// prefix.
+func KeyPrefixFilter(prefix string) FilterFunc {
+	return func(n Node) bool {
+		return strings.HasPrefix(n.Key, prefix)
+	}
+}
+
+// KeySuffixFilter returns a FilterFunc that checks if Node.Key ends with
+// suffix, e.g. "_id".
+func KeySuffixFilter(suffix string) FilterFunc {
+	return func(n Node) bool {
+		return strings.HasSuffix(n.Key, suffix)
+	}
+}
+
+// FullKeyPrefixFilter returns a FilterFunc that checks if Node.FullKey starts
+// with prefix, e.g. "spec.".
+func FullKeyPrefixFilter(prefix string) FilterFunc {
+	return func(n Node) bool {
+		return strings.HasPrefix(n.FullKey, prefix)
+	}
+}
+
+// KeyRegexFilter returns a FilterFunc that checks if Node.Key matches the
+// regular expression expr. The expression is not anchored, so use ^ and $ to
+// match the whole key. It is compiled once, and KeyRegexFilter panics if it
+// is malformed; compile it with regexp.Compile and use KeyMatchFilter to
+// handle the error instead.
+func KeyRegexFilter(expr string) FilterFunc {
+	return KeyMatchFilter(regexp.MustCompile(expr))
+}
+
+// KeyMatchFilter returns a FilterFunc that checks if Node.Key matches the
+// compiled regular expression re, e.g. one built by regexp.Compile from user
+// input.
+func KeyMatchFilter(re *regexp.Regexp) FilterFunc {
+	return func(n Node) bool {
+		return re.MatchString(n.Key)
+	}
+}
+
+// FullKeyRegexFilter returns a FilterFunc that checks if Node.FullKey matches
+// the regular expression expr, e.g. `^spec\.containers\[\d+\]\.image$`.
+// Like KeyRegexFilter, it panics if expr is malformed; use
+// FullKeyMatchFilter to handle the error instead.
+func FullKeyRegexFilter(expr string) FilterFunc {
+	return FullKeyMatchFilter(regexp.MustCompile(expr))
+}
+
+// FullKeyMatchFilter returns a FilterFunc that checks if Node.FullKey matches
+// the compiled regular expression re.
+func FullKeyMatchFilter(re *regexp.Regexp) FilterFunc {
+	return func(n Node) bool {
+		return re.MatchString(n.FullKey)
+	}
+}
+
+// KeyGlobFilter returns a FilterFunc that checks if the whole Node.Key
+// matches the glob pattern, where * matches any run of characters, ? matches
+// a single character and \ escapes the next one, e.g. "*_id". Use
+// PathPatternFilter to match whole paths.
+func KeyGlobFilter(pattern string) FilterFunc {
+	re := globRegexp(pattern)
+	return func(n Node) bool {
+		return re.MatchString(n.Key)
+	}
+}
+
+// globRegexp translates a glob pattern into an anchored regular expression.
+func globRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^(?s:")
+	for i := 0; i < len(pattern); {
+		r, size := utf8.DecodeRuneInString(pattern[i:])
+		switch {
+		case r == '*':
+			b.WriteString(".*")
+		case r == '?':
+			b.WriteString(".")
+		case r == '\\' && i+size < len(pattern):
+			i += size
+			_, size = utf8.DecodeRuneInString(pattern[i:])
+			fallthrough
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+size]))
+		}
+		i += size
+	}
+	b.WriteString(")$")
+	return regexp.MustCompile(b.String())
+}
+
+// PathPatternFilter returns a FilterFunc that checks if Node.Path() matches
+// pattern, e.g. "users[*].address.*". See PathPattern for the wildcards. The
+// pattern is compiled once, and PathPatternFilter panics if it is malformed;
+// use CompilePathPattern to handle the error instead.
+func PathPatternFilter(pattern string) FilterFunc {
+	p := MustCompilePathPattern(pattern)
+	return func(n Node) bool {
+		return p.Match(n.Path())
+	}
+}
+
 // FilterString returns a FilterFunc that checks if a node's value is a string
 // type and satisfies the provided filter condition.
 func FilterString(filter FilterFunc) FilterFunc {
@@ -89,3 +225,63 @@ func FilterFloat(filter FilterFunc) FilterFunc {
 		}
 	}
 }
+
+// FilterMap returns a FilterFunc that checks if a node's value is a map and
+// satisfies the provided filter condition.
+func FilterMap(filter FilterFunc) FilterFunc {
+	return func(n Node) bool {
+		return n.Value.Kind() == reflect.Map && filter(n)
+	}
+}
+
+// FilterSlice returns a FilterFunc that checks if a node's value is a slice or
+// an array and satisfies the provided filter condition.
+func FilterSlice(filter FilterFunc) FilterFunc {
+	return func(n Node) bool {
+		switch n.Value.Kind() {
+		case reflect.Slice, reflect.Array:
+			return filter(n)
+		default:
+			return false
+		}
+	}
+}
+
+// FilterStruct returns a FilterFunc that checks if a node's value is a struct
+// and satisfies the provided filter condition.
+func FilterStruct(filter FilterFunc) FilterFunc {
+	return func(n Node) bool {
+		return n.Value.Kind() == reflect.Struct && filter(n)
+	}
+}
+
+// FilterNil returns a FilterFunc that checks if a node holds no value, as
+// reported by Node.IsNil, and satisfies the provided filter condition.
+func FilterNil(filter FilterFunc) FilterFunc {
+	return func(n Node) bool {
+		return n.IsNil() && filter(n)
+	}
+}
+
+// FilterPointer returns a FilterFunc that checks if a node's raw value,
+// Node.Interface, is a pointer and satisfies the provided filter condition.
+// Node.Value already looks through the pointer, so the filter sees the value
+// it points to.
+func FilterPointer(filter FilterFunc) FilterFunc {
+	return func(n Node) bool {
+		return reflect.ValueOf(n.Interface).Kind() == reflect.Pointer && filter(n)
+	}
+}
+
+var (
+	mapType   = reflect.TypeOf(map[string]any(nil))
+	sliceType = reflect.TypeOf([]any(nil))
+)
+
+// filterType returns a FilterFunc that checks if a node's value has exactly
+// the type t and satisfies the provided filter condition.
+func filterType(t reflect.Type, filter FilterFunc) FilterFunc {
+	return func(n Node) bool {
+		return n.Value.IsValid() && n.Value.Type() == t && filter(n)
+	}
+}
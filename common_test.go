@@ -0,0 +1,155 @@
+package gotree
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestKeyFilters(t *testing.T) {
+	data := map[string]any{
+		"user_id": 1,
+		"ID":      2,
+		"name":    "Ann",
+		"spec": map[string]any{
+			"containers": []any{
+				map[string]any{"image": "nginx", "name": "web"},
+				map[string]any{"image": "redis", "name": "cache"},
+			},
+			"group_id": 3,
+		},
+	}
+
+	tests := []struct {
+		name   string
+		filter FilterFunc
+		want   []string
+	}{
+		{name: "KeyFoldFilter", filter: KeyFoldFilter("id"), want: []string{"ID"}},
+		{name: "KeyPrefixFilter", filter: KeyPrefixFilter("user"), want: []string{"user_id"}},
+		{
+			name:   "KeySuffixFilter",
+			filter: KeySuffixFilter("_id"),
+			want:   []string{"spec.group_id", "user_id"},
+		},
+		{
+			name:   "FullKeyPrefixFilter",
+			filter: FullKeyPrefixFilter("spec.containers[1]."),
+			want:   []string{"spec.containers[1].image", "spec.containers[1].name"},
+		},
+		{
+			name:   "KeyRegexFilter",
+			filter: KeyRegexFilter(`^[a-z]+_id$`),
+			want:   []string{"spec.group_id", "user_id"},
+		},
+		{
+			name:   "FullKeyRegexFilter",
+			filter: FullKeyRegexFilter(`^spec\.containers\[\d+\]\.image$`),
+			want:   []string{"spec.containers[0].image", "spec.containers[1].image"},
+		},
+		{
+			name:   "KeyMatchFilter",
+			filter: KeyMatchFilter(regexp.MustCompile(`(?i)^id$`)),
+			want:   []string{"ID"},
+		},
+		{
+			name:   "FullKeyMatchFilter",
+			filter: FullKeyMatchFilter(regexp.MustCompile(`\[1\]\.name$`)),
+			want:   []string{"spec.containers[1].name"},
+		},
+		{
+			name:   "KeyGlobFilter star",
+			filter: KeyGlobFilter("*_id"),
+			want:   []string{"spec.group_id", "user_id"},
+		},
+		{name: "KeyGlobFilter question", filter: KeyGlobFilter("?D"), want: []string{"ID"}},
+		{name: "KeyGlobFilter literal", filter: KeyGlobFilter("na.e"), want: nil},
+		{name: "KeyGlobFilter exact", filter: KeyGlobFilter("image"), want: []string{
+			"spec.containers[0].image", "spec.containers[1].image",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			Walk(data, func(n Node) bool {
+				if tt.filter(n) {
+					got = append(got, n.FullKey)
+				}
+				return true
+			}, WithKeyOrder(LexicalOrder))
+
+			if !EqualSlices(t, tt.want, got) {
+				t.Errorf("matched %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("TestGlobEscapes", func(t *testing.T) {
+		tests := []struct {
+			pattern, key string
+			want         bool
+		}{
+			{pattern: `a\*`, key: "a*", want: true},
+			{pattern: `a\*`, key: "ab", want: false},
+			{pattern: "?é", key: "xé", want: true},
+			{pattern: "é?", key: "éé", want: true},
+			{pattern: "a*", key: "a\nb", want: true},
+			{pattern: `a\`, key: `a\`, want: true},
+		}
+
+		for _, tt := range tests {
+			if got := globRegexp(tt.pattern).MatchString(tt.key); got != tt.want {
+				t.Errorf("glob %q on %q = %v, want %v", tt.pattern, tt.key, got, tt.want)
+			}
+		}
+	})
+
+	t.Run("TestKeyRegexFilterPanics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("KeyRegexFilter() did not panic")
+			}
+		}()
+		KeyRegexFilter("(")
+	})
+}
+
+func TestContainerFilters(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter FilterFunc
+		value  any
+		want   bool
+	}{
+		{name: "FilterMap", filter: FilterMap(NoneFilter), value: map[int]bool{}, want: true},
+		{name: "FilterMap pointer", filter: FilterMap(NoneFilter), value: &map[int]bool{}, want: true},
+		{name: "FilterMap slice", filter: FilterMap(NoneFilter), value: []int{}, want: false},
+		{name: "FilterSlice", filter: FilterSlice(NoneFilter), value: []int{}, want: true},
+		{name: "FilterSlice array", filter: FilterSlice(NoneFilter), value: [2]int{}, want: true},
+		{name: "FilterSlice string", filter: FilterSlice(NoneFilter), value: "ab", want: false},
+		{name: "FilterStruct", filter: FilterStruct(NoneFilter), value: testAddress{}, want: true},
+		{name: "FilterStruct map", filter: FilterStruct(NoneFilter), value: map[int]int{}, want: false},
+		{name: "FilterNil pointer", filter: FilterNil(NoneFilter), value: (*int)(nil), want: true},
+		{name: "FilterNil map", filter: FilterNil(NoneFilter), value: map[int]int(nil), want: true},
+		{name: "FilterNil zero", filter: FilterNil(NoneFilter), value: 0, want: false},
+		{name: "FilterPointer", filter: FilterPointer(NoneFilter), value: ptr(1), want: true},
+		{name: "FilterPointer nil", filter: FilterPointer(NoneFilter), value: (*int)(nil), want: true},
+		{name: "FilterPointer value", filter: FilterPointer(NoneFilter), value: 1, want: false},
+		{
+			name:   "FilterPointer sees target",
+			filter: FilterPointer(FilterInt(NoneFilter)),
+			value:  ptr(1),
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := newNode("v", "v", reflect.ValueOf(tt.value))
+			if got := tt.filter(n); got != tt.want {
+				t.Errorf("filter(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
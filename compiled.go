@@ -0,0 +1,176 @@
+package gotree
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+)
+
+// CompiledQuery is a path pattern or JSONPath expression that has been parsed
+// once so it can be run many times. It is immutable and safe for concurrent
+// use, so a single CompiledQuery can be shared by every request handler.
+type CompiledQuery struct {
+	source  string
+	walker  *Walker
+	path    *jsonPath
+	pattern *PathPattern
+}
+
+// Compile parses expr into a CompiledQuery. An expression that starts with $
+// is a JSONPath expression as accepted by Query; anything else is a path
+// pattern as accepted by CompilePathPattern. The options are stored in the
+// query and used every time it runs.
+//
+// Compile returns a *SyntaxError if expr is malformed.
+func Compile(expr string, opts ...Option) (*CompiledQuery, error) {
+	q := &CompiledQuery{source: expr, walker: NewWalker(opts...)}
+
+	var err error
+	if strings.HasPrefix(expr, "$") {
+		q.path, err = compileJSONPath(expr)
+	} else {
+		q.pattern, err = CompilePathPattern(expr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// MustCompile is like Compile but panics if expr is malformed. It simplifies
+// the initialization of global variables holding compiled queries.
+func MustCompile(expr string, opts ...Option) *CompiledQuery {
+	q, err := Compile(expr, opts...)
+	if err != nil {
+		panic("gotree: " + err.Error())
+	}
+	return q
+}
+
+// String returns the expression q was compiled from.
+func (q *CompiledQuery) String() string {
+	return q.source
+}
+
+// Nodes returns every node of tree matched by q. A path pattern works like
+// Traverse with PathPatternFilter and does not descend into a matched node,
+// while a JSONPath expression returns every node it selects, as Query does.
+// Nodes returns ErrNilTree if tree is nil and ErrNotFound if nothing matches.
+func (q *CompiledQuery) Nodes(tree any) ([]Node, error) {
+	if q.path != nil {
+		return q.path.query(tree, q.walker.opts)
+	}
+	return q.walker.traverseNodes(tree, q.matches, SkipChildren)
+}
+
+// Find returns the value of the first node of tree matched by q. The rest of
+// the tree is not searched.
+func (q *CompiledQuery) Find(tree any) (any, error) {
+	var (
+		node Node
+		err  error
+	)
+	if q.path != nil {
+		node, err = q.path.first(tree, q.walker.opts)
+	} else {
+		node, err = q.walker.findNode(tree, q.matches)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return node.Interface, nil
+}
+
+// Traverse returns the values of the nodes of tree returned by Nodes.
+func (q *CompiledQuery) Traverse(tree any) ([]any, error) {
+	nodes, err := q.Nodes(tree)
+	if err != nil {
+		return []any{}, err
+	}
+
+	values := make([]any, len(nodes))
+	for i, n := range nodes {
+		values[i] = n.Interface
+	}
+	return values, nil
+}
+
+// Has reports whether q matches any node of tree.
+func (q *CompiledQuery) Has(tree any) bool {
+	_, err := q.Find(tree)
+	return err == nil
+}
+
+// matches is the filter used to run a path pattern.
+func (q *CompiledQuery) matches(n Node) bool {
+	return q.pattern.Match(n.Path())
+}
+
+// QueryCache keeps the most recently used compiled queries, so expressions
+// that are built at runtime are only parsed the first time they are seen. It
+// is safe for concurrent use.
+type QueryCache struct {
+	size int
+	opts []Option
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // of *CompiledQuery, most recently used first
+}
+
+// NewQueryCache returns a QueryCache holding up to size queries, compiled
+// with opts. A size below 1 is treated as 1.
+func NewQueryCache(size int, opts ...Option) *QueryCache {
+	if size < 1 {
+		size = 1
+	}
+	return &QueryCache{
+		size:    size,
+		opts:    opts,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Compile returns the cached query for expr, compiling and caching it on
+// first use. When the cache is full, the least recently used query is
+// dropped. Expressions that fail to compile are not cached.
+func (c *QueryCache) Compile(expr string) (*CompiledQuery, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[expr]; ok {
+		c.lru.MoveToFront(e)
+		c.mu.Unlock()
+		return e.Value.(*CompiledQuery), nil
+	}
+	c.mu.Unlock()
+
+	// Compile outside the lock so a slow expression doesn't block lookups
+	q, err := Compile(expr, c.opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Another goroutine may have compiled it meanwhile
+	if e, ok := c.entries[expr]; ok {
+		c.lru.MoveToFront(e)
+		return e.Value.(*CompiledQuery), nil
+	}
+
+	c.entries[expr] = c.lru.PushFront(q)
+	if c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*CompiledQuery).source)
+	}
+	return q, nil
+}
+
+// Len returns the number of queries in the cache.
+func (c *QueryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
@@ -0,0 +1,201 @@
+package gotree
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestCompiledQuery(t *testing.T) {
+	data := map[string]any{
+		"users": []any{
+			map[string]any{"name": "Ann", "age": 31},
+			map[string]any{"name": "Bob", "age": 17},
+		},
+	}
+
+	t.Run("TestCompiledQueryKinds", func(t *testing.T) {
+		tests := []struct {
+			expr string
+			want []any
+		}{
+			{expr: "users[*].name", want: []any{"Ann", "Bob"}},
+			{expr: "**.age", want: []any{31, 17}},
+			{expr: "$.users[*].name", want: []any{"Ann", "Bob"}},
+			{expr: "$.users[?(@.age >= 18)].name", want: []any{"Ann"}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.expr, func(t *testing.T) {
+				q, err := Compile(tt.expr, WithKeyOrder(LexicalOrder))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if q.String() != tt.expr {
+					t.Errorf("String() = %q, want %q", q.String(), tt.expr)
+				}
+
+				got, err := q.Traverse(data)
+				if err != nil || !EqualSlices(t, tt.want, got) {
+					t.Errorf("Traverse() = (%v, %v), want %v", got, err, tt.want)
+				}
+
+				first, err := q.Find(data)
+				if err != nil || first != tt.want[0] {
+					t.Errorf("Find() = (%v, %v), want %v", first, err, tt.want[0])
+				}
+
+				if !q.Has(data) {
+					t.Errorf("Has() = false, want true")
+				}
+			})
+		}
+	})
+
+	t.Run("TestCompiledQueryNoMatch", func(t *testing.T) {
+		for _, expr := range []string{"users[*].email", "$.users[*].email"} {
+			q := MustCompile(expr)
+			if _, err := q.Find(data); !errors.Is(err, ErrNotFound) {
+				t.Errorf("%s: Find() error = %v, want ErrNotFound", expr, err)
+			}
+			if q.Has(data) {
+				t.Errorf("%s: Has() = true, want false", expr)
+			}
+			if _, err := q.Nodes(nil); !errors.Is(err, ErrNilTree) {
+				t.Errorf("%s: Nodes(nil) error = %v, want ErrNilTree", expr, err)
+			}
+		}
+	})
+
+	t.Run("TestCompiledQueryFindStops", func(t *testing.T) {
+		// The cycle after the first match is only reached by a full search
+		ring := map[string]any{"a": 2}
+		ring["self"] = ring
+		tree := map[string]any{"a": 1, "ring": ring}
+
+		for _, expr := range []string{"**.a", "$..a"} {
+			q := MustCompile(expr, WithKeyOrder(LexicalOrder), WithCycleMode(CycleError))
+			if got, err := q.Find(tree); err != nil || got != 1 {
+				t.Errorf("%s: Find() = (%v, %v), want 1", expr, got, err)
+			}
+			if !q.Has(tree) {
+				t.Errorf("%s: Has() = false, want true", expr)
+			}
+			if _, err := q.Nodes(tree); !errors.Is(err, ErrCycle) {
+				t.Errorf("%s: Nodes() error = %v, want %v", expr, err, ErrCycle)
+			}
+		}
+	})
+
+	t.Run("TestCompiledQueryNested", func(t *testing.T) {
+		tree := map[string]any{"a": map[string]any{"a": 1}}
+
+		got, err := MustCompile("**.a").Traverse(tree)
+		want, _ := Traverse(tree, PathPatternFilter("**.a"))
+		if err != nil || len(got) != 1 || len(want) != 1 {
+			t.Errorf("Traverse() = (%v, %v), want %v", got, err, want)
+		}
+
+		got, err = MustCompile("$..a").Traverse(tree)
+		if err != nil || len(got) != 2 {
+			t.Errorf("Traverse() = (%v, %v), want both a", got, err)
+		}
+	})
+
+	t.Run("TestCompiledQueryErrors", func(t *testing.T) {
+		for _, expr := range []string{"users[*", "$.users[", "a*"} {
+			_, err := Compile(expr)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Errorf("Compile(%q) error = %v, want *SyntaxError", expr, err)
+			}
+		}
+	})
+
+	t.Run("TestCompiledQueryConcurrent", func(t *testing.T) {
+		q := MustCompile("$..name", WithKeyOrder(LexicalOrder))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					got, err := q.Traverse(data)
+					if err != nil || len(got) != 2 {
+						t.Errorf("Traverse() = (%v, %v)", got, err)
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestQueryCache(t *testing.T) {
+	t.Run("TestQueryCacheReuse", func(t *testing.T) {
+		c := NewQueryCache(2)
+		a, err := c.Compile("a.b")
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, _ := c.Compile("a.b")
+		if a != again {
+			t.Errorf("Compile() compiled a cached expression again")
+		}
+	})
+
+	t.Run("TestQueryCacheEviction", func(t *testing.T) {
+		c := NewQueryCache(2)
+		a, _ := c.Compile("a")
+		c.Compile("b")
+		c.Compile("a") // a is now the most recently used
+		c.Compile("c") // evicts b
+
+		if c.Len() != 2 {
+			t.Errorf("Len() = %d, want 2", c.Len())
+		}
+		if got, _ := c.Compile("a"); got != a {
+			t.Errorf("Compile(a) was evicted")
+		}
+		if _, ok := c.entries["b"]; ok {
+			t.Errorf("Compile(b) was not evicted")
+		}
+	})
+
+	t.Run("TestQueryCacheErrors", func(t *testing.T) {
+		c := NewQueryCache(2)
+		if _, err := c.Compile("a["); err == nil {
+			t.Errorf("Compile() accepted a malformed expression")
+		}
+		if c.Len() != 0 {
+			t.Errorf("Len() = %d, want 0", c.Len())
+		}
+	})
+
+	t.Run("TestQueryCacheConcurrent", func(t *testing.T) {
+		c := NewQueryCache(4)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					expr := fmt.Sprintf("k%d", (i+j)%6)
+					if _, err := c.Compile(expr); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		if c.Len() > 4 {
+			t.Errorf("Len() = %d, want at most 4", c.Len())
+		}
+	})
+}
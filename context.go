@@ -0,0 +1,100 @@
+package gotree
+
+import (
+	"context"
+	"reflect"
+)
+
+// ctxCheckInterval is the number of nodes walked between two checks of the
+// context of a walk.
+const ctxCheckInterval = 64
+
+// VisitContext works like Visit, but ends the walk once ctx is done, so it
+// respects request timeouts even on huge trees or with slow visit functions.
+// The context is checked before the first node and then periodically, and the
+// returned error is a *PathError wrapping ctx.Err() whose FullKey is where the
+// walk stopped.
+func (w *Walker) VisitContext(ctx context.Context, tree any, visit VisitFunc) error {
+	if tree == nil {
+		return ErrNilTree
+	}
+
+	s := newWalkState(w.opts)
+	if ctx.Done() != nil {
+		s.ctx = ctx
+	}
+	s.run(newNode("", "", reflect.ValueOf(tree)), visit)
+	return s.err
+}
+
+// VisitContext works like Visit, but ends the walk once ctx is done. It is a
+// shorthand for NewWalker(opts...).VisitContext(ctx, tree, visit).
+func VisitContext(ctx context.Context, tree any, visit VisitFunc, opts ...Option) error {
+	return NewWalker(opts...).VisitContext(ctx, tree, visit)
+}
+
+// WalkContext works like Walk, but ends the walk once ctx is done and returns
+// the same errors as VisitContext.
+func (w *Walker) WalkContext(ctx context.Context, tree any, visit func(Node) bool) error {
+	return w.VisitContext(ctx, tree, func(n Node) Action {
+		if !visit(n) {
+			return Stop
+		}
+		return Continue
+	})
+}
+
+// WalkContext works like Walk, but ends the walk once ctx is done. It is a
+// shorthand for NewWalker(opts...).WalkContext(ctx, tree, visit).
+func WalkContext(ctx context.Context, tree any, visit func(Node) bool, opts ...Option) error {
+	return NewWalker(opts...).WalkContext(ctx, tree, visit)
+}
+
+// FindContext works like Find, but gives up once ctx is done and returns a
+// *PathError wrapping ctx.Err(), as VisitContext does.
+func FindContext(ctx context.Context, tree any, filter FilterFunc, opts ...Option) (any, error) {
+	node, err := NewWalker(opts...).findNodeContext(ctx, tree, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return node.Interface, nil
+}
+
+// TraverseContext works like Traverse, but gives up once ctx is done and
+// returns a *PathError wrapping ctx.Err(), as VisitContext does. The values
+// collected so far are dropped.
+func TraverseContext(ctx context.Context, tree any, filter FilterFunc, opts ...Option) ([]any, error) {
+	nodes, err := NewWalker(opts...).traverseNodesContext(ctx, tree, filter, SkipChildren)
+	if err != nil {
+		return []any{}, err
+	}
+
+	values := make([]any, len(nodes))
+	for i, v := range nodes {
+		values[i] = v.Interface
+	}
+	return values, nil
+}
+
+// canceled reports whether the context of the walk is done, checking it once
+// every ctxCheckInterval nodes. When it is, it records the error at node in
+// s.err.
+func (s *walkState) canceled(node Node) bool {
+	if s.ctx == nil {
+		return false
+	}
+
+	s.nodes++
+	if s.nodes%ctxCheckInterval != 1 {
+		return false
+	}
+
+	select {
+	case <-s.ctx.Done():
+		s.err = &PathError{FullKey: node.FullKey, Err: s.ctx.Err()}
+		return true
+	default:
+		return false
+	}
+}
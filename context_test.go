@@ -0,0 +1,86 @@
+package gotree
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestContext(t *testing.T) {
+	items := make([]any, 1000)
+	for i := range items {
+		items[i] = map[string]any{"id": i}
+	}
+	data := map[string]any{"items": items}
+
+	t.Run("TestWalkContextCancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		visited, canceledAt := 0, 0
+		err := WalkContext(ctx, data, func(n Node) bool {
+			visited++
+			if n.FullKey == "items[10].id" {
+				cancel()
+				canceledAt = visited
+			}
+			return true
+		})
+
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("WalkContext() error = %v, want %v", err, context.Canceled)
+		}
+		var pathErr *PathError
+		if !errors.As(err, &pathErr) || pathErr.FullKey == "" {
+			t.Errorf("WalkContext() error = %#v, want a *PathError with a FullKey", err)
+		}
+		if visited-canceledAt > ctxCheckInterval {
+			t.Errorf("visited %d nodes after cancel, want at most %d", visited-canceledAt, ctxCheckInterval)
+		}
+	})
+
+	t.Run("TestFindContextDeadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		_, err := FindContext(ctx, data, func(n Node) bool {
+			time.Sleep(50 * time.Microsecond)
+			return false
+		})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("FindContext() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("TestTraverseContextDone", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		got, err := TraverseContext(ctx, data, KeyFilter("id"))
+		want := fmt.Sprint(context.Canceled)
+		if len(got) != 0 || err == nil || err.Error() != want {
+			t.Errorf("TraverseContext() = (%v, %v), want ([], %v)", got, err, want)
+		}
+	})
+
+	t.Run("TestContextNotDone", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		got, err := TraverseContext(ctx, data, KeyFilter("id"))
+		if err != nil || len(got) != len(items) {
+			t.Errorf("TraverseContext() = (%d values, %v), want %d values", len(got), err, len(items))
+		}
+
+		found, err := FindContext(ctx, data, FullKeyFilter("items[999].id"))
+		if err != nil || found != 999 {
+			t.Errorf("FindContext() = (%v, %v), want 999", found, err)
+		}
+
+		if err := VisitContext(ctx, nil, nil); err != ErrNilTree {
+			t.Errorf("VisitContext(nil) error = %v, want %v", err, ErrNilTree)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package gotree
+
+import "reflect"
+
+// visitKey identifies a pointer, map or slice by its address and type. Slices
+// also carry their length, since a sub-slice shares the address of its backing
+// array.
+type visitKey struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// keyOf returns the visitKey of node and whether node can take part in a
+// cycle at all. Maps and slices are identified by themselves, anything else by
+// the pointer it was reached through. Nil and empty containers cannot cycle.
+func keyOf(node Node) (visitKey, bool) {
+	value := node.Value
+	switch value.Kind() {
+	case reflect.Map, reflect.Slice:
+		if value.IsNil() || value.Len() == 0 {
+			return visitKey{}, false
+		}
+	case reflect.Pointer:
+		if value.IsNil() {
+			return visitKey{}, false
+		}
+	default:
+		if !node.via.IsValid() {
+			return visitKey{}, false
+		}
+		value = node.via
+	}
+
+	key := visitKey{ptr: value.Pointer(), typ: value.Type()}
+	if value.Kind() == reflect.Slice {
+		key.len = value.Len()
+	}
+	return key, true
+}
+
+// enter marks node as being walked. It returns false if node is already being
+// walked further up the tree, in which case it must not be descended into.
+// With CycleError it also records ErrCycle in s.err.
+func (s *walkState) enter(node Node) bool {
+	// resolve leaves a non-nil pointer in place only when it points back
+	// to itself
+	cyclic := node.Value.Kind() == reflect.Pointer
+
+	key, ok := keyOf(node)
+	if ok && !cyclic {
+		cyclic = s.isWalking(node, key)
+	}
+
+	if cyclic {
+		if s.opts.Cycle == CycleError {
+			s.err = &PathError{FullKey: node.FullKey, Err: ErrCycle}
+		}
+		return false
+	}
+
+	if ok && s.visiting != nil {
+		s.visiting[key] = struct{}{}
+	}
+	return true
+}
+
+// isWalking reports whether key belongs to a node above node. Without the
+// visiting set, as in a breadth-first walk, the Parent chain of node is
+// searched instead.
+func (s *walkState) isWalking(node Node, key visitKey) bool {
+	if s.visiting == nil {
+		for p := node.Parent; p != nil; p = p.Parent {
+			if k, ok := keyOf(*p); ok && k == key {
+				return true
+			}
+		}
+		return false
+	}
+
+	if _, ok := s.visiting[key]; ok {
+		return true
+	}
+	_, ok := s.ancestors[key]
+	return ok
+}
+
+// leave undoes enter once node and everything below it has been walked.
+func (s *walkState) leave(node Node) {
+	if key, ok := keyOf(node); ok {
+		delete(s.visiting, key)
+	}
+}
+
+// resolve follows interfaces and pointers from value to the value they
+// lead to. It stops at a nil interface or pointer, and at a pointer that leads
+// back to itself. via is the last pointer that was dereferenced, if any.
+func resolve(value reflect.Value) (resolved, via reflect.Value) {
+	var buf [4]visitKey
+	seen := buf[:0]
+
+	for {
+		switch value.Kind() {
+		case reflect.Interface:
+			if value.IsNil() {
+				return value, via
+			}
+			value = value.Elem()
+		case reflect.Pointer:
+			if value.IsNil() {
+				return value, via
+			}
+
+			key := visitKey{ptr: value.Pointer(), typ: value.Type()}
+			for _, k := range seen {
+				if k == key {
+					return value, via
+				}
+			}
+			seen = append(seen, key)
+
+			via = value
+			value = value.Elem()
+		default:
+			return value, via
+		}
+	}
+}
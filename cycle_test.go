@@ -0,0 +1,92 @@
+package gotree
+
+import (
+	"errors"
+	"testing"
+)
+
+type testLink struct {
+	Name string
+	Next *testLink
+}
+
+func TestCycles(t *testing.T) {
+	selfMap := map[string]any{"name": "root"}
+	selfMap["self"] = selfMap
+
+	selfSlice := []any{"first", nil}
+	selfSlice[1] = selfSlice
+
+	ring := &testLink{Name: "a", Next: &testLink{Name: "b"}}
+	ring.Next.Next = ring
+
+	shared := map[string]any{"name": "shared"}
+	diamond := map[string]any{"left": shared, "right": shared}
+
+	tests := []struct {
+		name      string
+		tree      any
+		want      []string
+		wantCycle bool
+	}{
+		{
+			name:      "Map holding itself",
+			tree:      selfMap,
+			want:      []string{"root"},
+			wantCycle: true,
+		},
+		{
+			name:      "Slice holding itself",
+			tree:      selfSlice,
+			want:      []string{"first"},
+			wantCycle: true,
+		},
+		{
+			name:      "Pointer ring",
+			tree:      ring,
+			want:      []string{"a", "b"},
+			wantCycle: true,
+		},
+		{
+			name: "Shared subtree is not a cycle",
+			tree: diamond,
+			want: []string{"shared", "shared"},
+		},
+	}
+
+	t.Run("TestCycleSkip", func(t *testing.T) {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := TraverseString(tt.tree, NoneFilter)
+				if err != nil {
+					t.Fatalf("TraverseString() error = %v", err)
+				}
+				if !EqualSlices(t, tt.want, got) {
+					t.Errorf("TraverseString() = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("TestCycleError", func(t *testing.T) {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := TraverseString(tt.tree, NoneFilter,
+					WithCycleMode(CycleError))
+				if errors.Is(err, ErrCycle) != tt.wantCycle {
+					t.Errorf("TraverseString() error = %v, want cycle %v", err, tt.wantCycle)
+				}
+			})
+		}
+
+		_, err := Find(ring, KeyFilter("missing"), WithCycleMode(CycleError))
+		var pathErr *PathError
+		if !errors.As(err, &pathErr) || pathErr.FullKey != "Next.Next" {
+			t.Errorf("Find() error = %v, want cycle at Next.Next", err)
+		}
+
+		if Has(selfMap, KeyFilter("missing"), WithCycleMode(CycleError)) {
+			t.Errorf("Has() = true, want false")
+		}
+	})
+}
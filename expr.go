@@ -0,0 +1,109 @@
+package gotree
+
+import "reflect"
+
+// ParseFilter parses a filter expression into a FilterFunc, so rules that
+// select nodes can be kept in configuration files, e.g.
+//
+//	key == "email" && kind == string && value =~ "@corp\\.com$"
+//
+// An expression compares the properties of a node with literals, or with each
+// other. The properties are:
+//
+//   - key: the key of the node, as in Node.Key
+//   - fullkey: the dotted path of the node, as in Node.FullKey
+//   - depth: the depth of the node, the root being 0
+//   - kind: the kind of the value, as named by reflect.Kind, e.g. string,
+//     int, float64, bool, map, slice or struct; as with Node.Kind, a pointer
+//     has the kind of the value it points to, so only a nil pointer is ptr,
+//     and null is invalid
+//   - value: the value itself; every integer and float kind is a number
+//
+// Literals are single or double quoted strings, numbers, true, false, null
+// and the names of the kinds. The operators are ==, !=, <, <=, >, >=, =~ and
+// !~, whose right side is a regular expression in a string, and &&, || and !
+// with parentheses for grouping. Values of different types are never equal,
+// and only numbers and strings are ordered.
+//
+// ParseFilter returns a *SyntaxError, whose Offset points at the problem, if
+// expr is malformed.
+func ParseFilter(expr string) (FilterFunc, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &jpParser{input: expr, tokens: tokens, filter: true}
+	e, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokEOF {
+		return nil, p.errorf("unexpected %q", p.peek().text)
+	}
+
+	return func(n Node) bool {
+		return e.test(nil, n)
+	}, nil
+}
+
+// MustParseFilter is like ParseFilter but panics if expr is malformed.
+func MustParseFilter(expr string) FilterFunc {
+	f, err := ParseFilter(expr)
+	if err != nil {
+		panic("gotree: " + err.Error())
+	}
+	return f
+}
+
+// nodeProperty is an operand of a filter expression that reads a property of
+// the node being tested.
+type nodeProperty string
+
+const (
+	propKey     nodeProperty = "key"
+	propFullKey nodeProperty = "fullkey"
+	propDepth   nodeProperty = "depth"
+	propKind    nodeProperty = "kind"
+	propValue   nodeProperty = "value"
+)
+
+func (prop nodeProperty) value(_ *jpContext, cur Node) jpValue {
+	switch prop {
+	case propKey:
+		return jpValue{kind: jpString, str: cur.Key}
+	case propFullKey:
+		return jpValue{kind: jpString, str: cur.FullKey}
+	case propDepth:
+		return jpValue{kind: jpNumber, num: float64(cur.Depth)}
+	case propKind:
+		return jpValue{kind: jpString, str: cur.Kind().String()}
+	default:
+		return valueOfNode(cur)
+	}
+}
+
+// kindNames maps the names of the reflect kinds to themselves, so kind can be
+// compared with a bare name as in kind == string.
+var kindNames = func() map[string]bool {
+	names := make(map[string]bool)
+	for k := reflect.Invalid; k <= reflect.UnsafePointer; k++ {
+		names[k.String()] = true
+	}
+	return names
+}()
+
+// property parses a node property, or the name of a kind.
+func (p *jpParser) property() (jpOperand, error) {
+	tok := p.peek()
+	switch prop := nodeProperty(tok.text); {
+	case prop == propKey, prop == propFullKey, prop == propDepth,
+		prop == propKind, prop == propValue:
+		p.advance()
+		return prop, nil
+	case kindNames[tok.text]:
+		p.advance()
+		return jpLiteral{jpValue{kind: jpString, str: tok.text}}, nil
+	}
+	return nil, p.errorf("unknown identifier %q", tok.text)
+}
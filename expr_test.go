@@ -0,0 +1,113 @@
+package gotree
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	data := map[string]any{
+		"name":  "Ann",
+		"email": "ann@corp.com",
+		"age":   31,
+		"admin": true,
+		"boss":  nil,
+		"link":  &testLink{Name: "a"},
+		"contacts": []any{
+			map[string]any{"email": "bob@home.org", "age": 17},
+		},
+	}
+
+	t.Run("TestParseFilterMatches", func(t *testing.T) {
+		tests := []struct {
+			expr string
+			want []string
+		}{
+			{expr: `key == "email"`, want: []string{"contacts[0].email", "email"}},
+			{
+				expr: `key == "email" && kind == string && value =~ "@corp\\.com$"`,
+				want: []string{"email"},
+			},
+			{expr: `value !~ '^a' && kind == string`, want: []string{"contacts[0].email", "name"}},
+			{expr: `key == 'age' && value >= 18`, want: []string{"age"}},
+			{expr: `depth > 2`, want: []string{"contacts[0].age", "contacts[0].email"}},
+			{expr: `fullkey == "contacts[0].age"`, want: []string{"contacts[0].age"}},
+			{expr: `value == true || value == null`, want: []string{"admin", "boss", "link.Next"}},
+			{expr: `kind == invalid`, want: []string{"boss"}},
+			{expr: `!(kind == string || kind == int) && depth == 1`, want: []string{"admin", "boss", "contacts", "link"}},
+			{expr: `key == fullkey && kind == int`, want: []string{"age"}},
+			{expr: `kind == struct || kind == ptr`, want: []string{"link", "link.Next"}},
+			{expr: `kind == ptr`, want: []string{"link.Next"}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.expr, func(t *testing.T) {
+				filter, err := ParseFilter(tt.expr)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var got []string
+				Walk(data, func(n Node) bool {
+					if filter(n) {
+						got = append(got, n.FullKey)
+					}
+					return true
+				}, WithKeyOrder(LexicalOrder))
+
+				if !EqualSlices(t, tt.want, got) {
+					t.Errorf("matched %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("TestParseFilterErrors", func(t *testing.T) {
+		tests := []struct {
+			expr   string
+			offset int
+		}{
+			{expr: `key ==`, offset: 6},
+			{expr: `key == "email" &&`, offset: 17},
+			{expr: `name == "x"`, offset: 0},
+			{expr: `key`, offset: 3},
+			{expr: `key == 'x' )`, offset: 11},
+			{expr: `value =~ "("`, offset: 9},
+			{expr: `key == "x`, offset: 7},
+			{expr: `@.key == "x"`, offset: 0},
+			{expr: `(key == "x"`, offset: 11},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.expr, func(t *testing.T) {
+				_, err := ParseFilter(tt.expr)
+				var syntaxErr *SyntaxError
+				if !errors.As(err, &syntaxErr) {
+					t.Fatalf("ParseFilter() error = %v, want *SyntaxError", err)
+				}
+				if syntaxErr.Offset != tt.offset {
+					t.Errorf("Offset = %d, want %d (%v)", syntaxErr.Offset, tt.offset, err)
+				}
+			})
+		}
+	})
+
+	t.Run("TestParseFilterErrorColumn", func(t *testing.T) {
+		_, err := ParseFilter(`key == "ééé" && `)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) || syntaxErr.Offset != 19 {
+			t.Fatalf("ParseFilter() error = %#v, want a *SyntaxError at offset 19", err)
+		}
+		if !strings.Contains(err.Error(), "at column 17 ") {
+			t.Errorf("Error() = %q, want column 17", err)
+		}
+	})
+
+	t.Run("TestParseFilterFind", func(t *testing.T) {
+		got, err := FindString(data, MustParseFilter(`value =~ "@home"`))
+		if err != nil || got != "bob@home.org" {
+			t.Errorf("FindString() = (%v, %v), want bob@home.org", got, err)
+		}
+	})
+}
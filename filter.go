@@ -0,0 +1,204 @@
+package gotree
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Filter is a filter that can describe itself, so the reason a node was or
+// was not matched can be logged with Explain. FilterFunc implements it, and
+// And, Or, Not, AnyOf and AllOf build new filters from existing ones. Pass the
+// Match method wherever a FilterFunc is expected:
+//
+//	adult := And(Describe("age", KeyFilter("age")), Describe("over 18", over18))
+//	nodes, err := Traverse(tree, adult.Match)
+type Filter interface {
+	// Match reports whether the filter accepts n.
+	Match(n Node) bool
+
+	// String returns a human-readable description of the filter.
+	String() string
+}
+
+// Match calls f(n).
+func (f FilterFunc) Match(n Node) bool {
+	return f(n)
+}
+
+// String returns the name of the function behind f, e.g. NoneFilter. Closures
+// are named after the function that created them. Use Describe to give a
+// FilterFunc a better description.
+func (f FilterFunc) String() string {
+	if f == nil {
+		return "<nil>"
+	}
+
+	name := runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// Describe returns a Filter that matches like f and is described by desc.
+func Describe(desc string, f FilterFunc) Filter {
+	return describedFilter{desc: desc, f: f}
+}
+
+type describedFilter struct {
+	desc string
+	f    FilterFunc
+}
+
+func (d describedFilter) Match(n Node) bool { return d.f(n) }
+func (d describedFilter) String() string    { return d.desc }
+
+// And returns a Filter that matches when every filter does. The filters are
+// tried in order and the first one that fails ends the test. And with no
+// filters matches every node.
+func And(filters ...Filter) Filter {
+	return andFilter(filters)
+}
+
+// AllOf is like And, for filters collected in a slice, e.g. from
+// configuration.
+func AllOf(filters []Filter) Filter {
+	return And(filters...)
+}
+
+// Or returns a Filter that matches when any filter does. The filters are
+// tried in order and the first one that matches ends the test. Or with no
+// filters matches no node.
+func Or(filters ...Filter) Filter {
+	return orFilter(filters)
+}
+
+// AnyOf is like Or, for filters collected in a slice, e.g. from
+// configuration.
+func AnyOf(filters []Filter) Filter {
+	return Or(filters...)
+}
+
+// Not returns a Filter that matches when f does not.
+func Not(f Filter) Filter {
+	return notFilter{f}
+}
+
+// Explain tests n with f and describes the outcome. For the filters built by
+// And, Or and Not it names the parts that decided it, e.g.
+//
+//	(age && over 18) did not match: over 18 did not match
+func Explain(f Filter, n Node) string {
+	_, why := explain(f, n)
+	return why
+}
+
+// explainer is implemented by the combinators to explain their outcome in
+// terms of their parts.
+type explainer interface {
+	explain(n Node) (bool, string)
+}
+
+// explain tests n with f and describes the outcome.
+func explain(f Filter, n Node) (bool, string) {
+	if e, ok := f.(explainer); ok {
+		return e.explain(n)
+	}
+	matched := f.Match(n)
+	return matched, outcome(f, matched)
+}
+
+// outcome describes whether f matched.
+func outcome(f Filter, matched bool) string {
+	if matched {
+		return f.String() + " matched"
+	}
+	return f.String() + " did not match"
+}
+
+// join describes filters joined by op, e.g. (a && b).
+func join(filters []Filter, op string) string {
+	parts := make([]string, len(filters))
+	for i, f := range filters {
+		parts[i] = f.String()
+	}
+	return "(" + strings.Join(parts, " "+op+" ") + ")"
+}
+
+type andFilter []Filter
+
+func (a andFilter) Match(n Node) bool {
+	for _, f := range a {
+		if !f.Match(n) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a andFilter) String() string {
+	if len(a) == 0 {
+		return "true"
+	}
+	return join(a, "&&")
+}
+
+func (a andFilter) explain(n Node) (bool, string) {
+	for _, f := range a {
+		if ok, why := explain(f, n); !ok {
+			return false, outcome(a, false) + ": " + why
+		}
+	}
+	return true, outcome(a, true)
+}
+
+type orFilter []Filter
+
+func (o orFilter) Match(n Node) bool {
+	for _, f := range o {
+		if f.Match(n) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o orFilter) String() string {
+	if len(o) == 0 {
+		return "false"
+	}
+	return join(o, "||")
+}
+
+func (o orFilter) explain(n Node) (bool, string) {
+	whys := make([]string, len(o))
+	for i, f := range o {
+		ok, why := explain(f, n)
+		if ok {
+			return true, outcome(o, true) + ": " + why
+		}
+		whys[i] = why
+	}
+	return false, outcome(o, false) + ": " + strings.Join(whys, "; ")
+}
+
+type notFilter struct {
+	f Filter
+}
+
+func (not notFilter) Match(n Node) bool {
+	return !not.f.Match(n)
+}
+
+func (not notFilter) String() string {
+	return "!" + not.f.String()
+}
+
+func (not notFilter) explain(n Node) (bool, string) {
+	ok, why := explain(not.f, n)
+	return !ok, outcome(not, !ok) + ": " + why
+}
@@ -0,0 +1,111 @@
+package gotree
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCombinators(t *testing.T) {
+	isEmail := Describe("email", KeyFilter("email"))
+	isString := Describe("string", func(n Node) bool {
+		return n.Value.Kind() == reflect.String
+	})
+	isAge := Describe("age", KeyFilter("age"))
+
+	email := Node{Key: "email", Value: reflect.ValueOf("a@b.c")}
+	age := Node{Key: "age", Value: reflect.ValueOf(31)}
+
+	t.Run("TestCombinatorsMatch", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			filter Filter
+			node   Node
+			want   bool
+		}{
+			{name: "And match", filter: And(isEmail, isString), node: email, want: true},
+			{name: "And fail", filter: And(isEmail, isString), node: age, want: false},
+			{name: "And empty", filter: And(), node: age, want: true},
+			{name: "AllOf", filter: AllOf([]Filter{isAge, Not(isString)}), node: age, want: true},
+			{name: "Or match", filter: Or(isEmail, isAge), node: age, want: true},
+			{name: "Or fail", filter: Or(isEmail, isString), node: age, want: false},
+			{name: "Or empty", filter: Or(), node: age, want: false},
+			{name: "AnyOf", filter: AnyOf([]Filter{isEmail, isAge}), node: email, want: true},
+			{name: "Not", filter: Not(isEmail), node: email, want: false},
+			{name: "FilterFunc", filter: FilterFunc(NoneFilter), node: email, want: true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if got := tt.filter.Match(tt.node); got != tt.want {
+					t.Errorf("Match() = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("TestCombinatorsShortCircuit", func(t *testing.T) {
+		calls := 0
+		counted := FilterFunc(func(Node) bool {
+			calls++
+			return true
+		})
+
+		And(isAge, counted).Match(email)
+		Or(isEmail, counted).Match(email)
+		if calls != 0 {
+			t.Errorf("filter called %d times, want 0", calls)
+		}
+	})
+
+	t.Run("TestCombinatorsExplain", func(t *testing.T) {
+		tests := []struct {
+			filter Filter
+			node   Node
+			want   string
+		}{
+			{
+				filter: And(isEmail, isString),
+				node:   age,
+				want:   "(email && string) did not match: email did not match",
+			},
+			{
+				filter: Or(isEmail, Not(isAge)),
+				node:   age,
+				want: "(email || !age) did not match: email did not match; " +
+					"!age did not match: age matched",
+			},
+			{
+				filter: Or(isEmail, And(isAge, Not(isString))),
+				node:   age,
+				want:   "(email || (age && !string)) matched: (age && !string) matched",
+			},
+			{filter: isEmail, node: email, want: "email matched"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.want, func(t *testing.T) {
+				if got := Explain(tt.filter, tt.node); got != tt.want {
+					t.Errorf("Explain() = %q, want %q", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("TestFilterFuncString", func(t *testing.T) {
+		if got := FilterFunc(NoneFilter).String(); got != "NoneFilter" {
+			t.Errorf("String() = %q, want NoneFilter", got)
+		}
+		if got := KeyFilter("x").String(); !strings.HasPrefix(got, "KeyFilter.") {
+			t.Errorf("String() = %q, want a closure of KeyFilter", got)
+		}
+	})
+
+	t.Run("TestCombinatorsFind", func(t *testing.T) {
+		data := map[string]any{"email": "a@b.c", "age": 31, "name": "Ann"}
+		got, err := Find(data, And(Not(isEmail), isString).Match)
+		if err != nil || got != "Ann" {
+			t.Errorf("Find() = (%v, %v), want Ann", got, err)
+		}
+	})
+}
@@ -1,82 +1,36 @@
 package gotree
 
-import (
-	"fmt"
-	"reflect"
-)
+import "context"
 
-// findHelper recursively searches a node and returns the first value that
-// matches the filter function. It traverses maps, slices, arrays, structs, and
-// interfaces through type reflection. When a matching node is found (filter
-// returns true), it immediately returns that value and stops traversal.
-func findHelper(node Node, filter FilterFunc) (Node, bool) {
-	switch node.Value.Kind() {
-	case reflect.Map:
-		for _, k := range node.Value.MapKeys() {
-			strKey := fmt.Sprint(k.Interface())
-			newFullKey := strKey
-			if node.FullKey != "" {
-				newFullKey = node.FullKey + "." + strKey
-			}
-
-			v := node.Value.MapIndex(k)
-			childNode := newNode(newFullKey, strKey, v)
-			if filter(childNode) {
-				return childNode, true
-			}
-			if result, found := findHelper(childNode, filter); found {
-				return result, true
-			}
-		}
-	case reflect.Slice, reflect.Array:
-		for i := 0; i < node.Value.Len(); i++ {
-			strKey := fmt.Sprintf("[%d]", i)
-			newFullKey := strKey
-			if node.FullKey != "" {
-				newFullKey = node.FullKey + strKey
-			}
-
-			v := node.Value.Index(i)
-			childNode := newNode(newFullKey, strKey, v)
-			if filter(childNode) {
-				return childNode, true
-			}
-			if result, found := findHelper(childNode, filter); found {
-				return result, true
-			}
-		}
-	case reflect.Struct:
-		reflectType := node.Value.Type()
-		for i := 0; i < node.Value.NumField(); i++ {
-			field := reflectType.Field(i)
-			if !field.IsExported() {
-				continue
-			}
+// findNode walks the tree with w and returns the first node that matches the
+// filter function. When a matching node is found (filter returns true), the
+// walk stops immediately.
+func (w *Walker) findNode(tree any, filter FilterFunc) (Node, error) {
+	return w.findNodeContext(context.Background(), tree, filter)
+}
 
-			newFullKey := field.Name
-			if node.FullKey != "" {
-				newFullKey = node.FullKey + "." + field.Name
-			}
+// findNodeContext is findNode, ending early with an error once ctx is done.
+func (w *Walker) findNodeContext(ctx context.Context, tree any, filter FilterFunc) (Node, error) {
+	var (
+		result Node
+		found  bool
+	)
 
-			v := node.Value.Field(i)
-			childNode := newNode(newFullKey, field.Name, v)
-			if filter(childNode) {
-				return childNode, true
-			}
-			if result, found := findHelper(childNode, filter); found {
-				return result, true
-			}
-		}
-	case reflect.Interface:
-		node.Value = node.Value.Elem()
-		return findHelper(node, filter)
-	default:
-		node := newNode(node.FullKey, node.Key, node.Value)
-		if filter(node) {
-			return node, true
+	err := w.VisitContext(ctx, tree, func(n Node) Action {
+		if !test(n, filter) {
+			return Continue
 		}
+		result, found = n, true
+		return Stop
+	})
+	if err != nil {
+		return Node{}, err
 	}
-	return Node{}, false
+	if !found {
+		return Node{}, ErrNotFound
+	}
+
+	return result, nil
 }
 
 // Find returns the first value that matches the given filter function. It
@@ -92,30 +46,21 @@ func findHelper(node Node, filter FilterFunc) (Node, bool) {
 //
 // Returns:
 //   - The first matching value, or error if no match is found or tree is nil.
-func Find(tree any, filter FilterFunc) (any, error) {
-	if tree == nil {
-		return nil, ErrNilTree
-	}
-
-	node := newNode("", "", reflect.ValueOf(tree))
-	if v, exists := findHelper(node, filter); exists {
-		return v.Interface, nil
+func Find(tree any, filter FilterFunc, opts ...Option) (any, error) {
+	node, err := NewWalker(opts...).findNode(tree, filter)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, ErrNotFound
+	return node.Interface, nil
 }
 
 // FindString searches for the first string value that matches the filter.
 // Returns the string if found, otherwise returns an error.
-func FindString(tree any, filter FilterFunc) (string, error) {
-	if tree == nil {
-		return "", ErrNilTree
-	}
-
-	node := newNode("", "", reflect.ValueOf(tree))
-	val, ok := findHelper(node, FilterString(filter))
-	if !ok || val.Interface == nil {
-		return "", ErrNotFound
+func FindString(tree any, filter FilterFunc, opts ...Option) (string, error) {
+	val, err := NewWalker(opts...).findNode(tree, FilterString(filter))
+	if err != nil {
+		return "", err
 	}
 
 	return val.Value.String(), nil
@@ -123,60 +68,62 @@ func FindString(tree any, filter FilterFunc) (string, error) {
 
 // FindBool searches for the first bool value that matches the filter. Returns
 // the bool if found, otherwise returns an error.
-func FindBool(tree any, filter FilterFunc) (bool, error) {
-	if tree == nil {
-		return false, ErrNilTree
-	}
-
-	node := newNode("", "", reflect.ValueOf(tree))
-	val, ok := findHelper(node, FilterBool(filter))
-	if !ok || val.Interface == nil {
-		return false, ErrNotFound
+func FindBool(tree any, filter FilterFunc, opts ...Option) (bool, error) {
+	val, err := NewWalker(opts...).findNode(tree, FilterBool(filter))
+	if err != nil {
+		return false, err
 	}
 	return val.Value.Bool(), nil
 }
 
 // FindInt searches for the first int value that matches the filter. Returns the
 // int64 if found, otherwise returns an error.
-func FindInt(tree any, filter FilterFunc) (int64, error) {
-	if tree == nil {
-		return 0, ErrNilTree
-	}
-
-	node := newNode("", "", reflect.ValueOf(tree))
-	val, ok := findHelper(node, FilterInt(filter))
-	if !ok || val.Interface == nil {
-		return 0, ErrNotFound
+func FindInt(tree any, filter FilterFunc, opts ...Option) (int64, error) {
+	val, err := NewWalker(opts...).findNode(tree, FilterInt(filter))
+	if err != nil {
+		return 0, err
 	}
 	return val.Value.Int(), nil
 }
 
 // FindUint searches for the first uint value that matches the filter. Returns
 // the uint64 if found, otherwise returns an error.
-func FindUint(tree any, filter FilterFunc) (uint64, error) {
-	if tree == nil {
-		return 0, ErrNilTree
-	}
-
-	node := newNode("", "", reflect.ValueOf(tree))
-	val, ok := findHelper(node, FilterUint(filter))
-	if !ok || val.Interface == nil {
-		return 0, ErrNotFound
+func FindUint(tree any, filter FilterFunc, opts ...Option) (uint64, error) {
+	val, err := NewWalker(opts...).findNode(tree, FilterUint(filter))
+	if err != nil {
+		return 0, err
 	}
 	return val.Value.Uint(), nil
 }
 
 // FindFloat searches for the first float value that matches the filter. Returns
 // the float64 if found, otherwise returns an error.
-func FindFloat(tree any, filter FilterFunc) (float64, error) {
-	if tree == nil {
-		return 0, ErrNilTree
+func FindFloat(tree any, filter FilterFunc, opts ...Option) (float64, error) {
+	val, err := NewWalker(opts...).findNode(tree, FilterFloat(filter))
+	if err != nil {
+		return 0, err
 	}
+	return val.Value.Float(), nil
+}
 
-	node := newNode("", "", reflect.ValueOf(tree))
-	val, ok := findHelper(node, FilterFloat(filter))
-	if !ok || val.Interface == nil {
-		return 0, ErrNotFound
+// FindMap searches for the first map[string]any, the type encoding/json
+// decodes objects into, that matches the filter. Returns the map if found,
+// otherwise returns an error.
+func FindMap(tree any, filter FilterFunc, opts ...Option) (map[string]any, error) {
+	val, err := NewWalker(opts...).findNode(tree, filterType(mapType, filter))
+	if err != nil {
+		return nil, err
 	}
-	return val.Value.Float(), nil
+	return val.Value.Interface().(map[string]any), nil
+}
+
+// FindSlice searches for the first []any, the type encoding/json decodes
+// arrays into, that matches the filter. Returns the slice if found, otherwise
+// returns an error.
+func FindSlice(tree any, filter FilterFunc, opts ...Option) ([]any, error) {
+	val, err := NewWalker(opts...).findNode(tree, filterType(sliceType, filter))
+	if err != nil {
+		return nil, err
+	}
+	return val.Value.Interface().([]any), nil
 }
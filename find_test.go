@@ -56,6 +56,42 @@ var testData = map[string]any{
 	},
 }
 
+type testAddress struct {
+	City *string
+	Zip  string
+}
+
+type testUser struct {
+	Name    string
+	Age     *int
+	Address *testAddress
+	Manager *testUser
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// Sample test data stored behind pointers
+var pointerData = &struct {
+	Owner *testUser
+	Users map[string]*testUser
+}{
+	Owner: &testUser{
+		Name:    "Carol",
+		Age:     ptr(41),
+		Address: &testAddress{City: ptr("Dhaka"), Zip: "1207"},
+	},
+	Users: map[string]*testUser{
+		"dave": {
+			Name:    "Dave",
+			Age:     ptr(35),
+			Address: &testAddress{City: ptr("Paris"), Zip: "75001"},
+			Manager: &testUser{Name: "Erin"},
+		},
+	},
+}
+
 func TestFindFunctions(t *testing.T) {
 	t.Run("TestFind", func(t *testing.T) {
 		tests := []struct {
@@ -530,4 +566,81 @@ func TestFindFunctions(t *testing.T) {
 			})
 		}
 	})
+	t.Run("TestFindPointers", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			tree      any
+			filter    func(Node) bool
+			wantValue string
+			wantFound bool
+		}{
+			{
+				name: "Find field of pointer struct",
+				tree: pointerData,
+				filter: func(n Node) bool {
+					return n.FullKey == "Owner.Name"
+				},
+				wantValue: "Carol",
+				wantFound: true,
+			},
+			{
+				name: "Find string behind pointer",
+				tree: pointerData,
+				filter: func(n Node) bool {
+					return n.FullKey == "Owner.Address.City"
+				},
+				wantValue: "Dhaka",
+				wantFound: true,
+			},
+			{
+				name: "Find in map of pointers",
+				tree: pointerData,
+				filter: func(n Node) bool {
+					return n.FullKey == "Users.dave.Manager.Name"
+				},
+				wantValue: "Erin",
+				wantFound: true,
+			},
+			{
+				name: "Nil pointer is not descended",
+				tree: pointerData,
+				filter: func(n Node) bool {
+					return n.FullKey == "Users.dave.Manager.Manager.Name"
+				},
+				wantValue: "",
+				wantFound: false,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := FindString(tt.tree, tt.filter)
+				if got != tt.wantValue || (err == nil) != tt.wantFound {
+					t.Errorf("FindString() = (%v, %v), want (%v, %v)", got, err, tt.wantValue, tt.wantFound)
+				}
+			})
+		}
+	})
+
+	t.Run("TestFindContainers", func(t *testing.T) {
+		nested, err := FindMap(testData, KeyFilter("nested"))
+		if err != nil || nested["deep_int"] != 100 {
+			t.Errorf("FindMap() = (%v, %v), want the nested map", nested, err)
+		}
+
+		user, err := FindMap(testData, FullKeyFilter("users[1]"))
+		if err != nil || user["name"] != "Bob" {
+			t.Errorf("FindMap() = (%v, %v), want Bob", user, err)
+		}
+
+		array, err := FindSlice(testData, KeyFilter("array"))
+		if err != nil || len(array) != 6 {
+			t.Errorf("FindSlice() = (%v, %v), want the array", array, err)
+		}
+
+		// users is a []map[string]any, not a []any
+		if got, err := FindSlice(testData, KeyFilter("users")); err == nil {
+			t.Errorf("FindSlice() = %v, want an error", got)
+		}
+	})
 }
@@ -0,0 +1,68 @@
+package gotree
+
+// FindAs searches for the first value of type T that matches the filter and
+// returns it as a T. A node matches when its raw value, Node.Interface, or the
+// value it resolves to, Node.Value, can be asserted to T. So FindAs[time.Time]
+// finds both time.Time and *time.Time fields, FindAs[*time.Time] only the
+// pointers, and an interface type T finds every value implementing it.
+//
+// The filter is only called for nodes of type T. Returns an error if no value
+// matches or tree is nil.
+func FindAs[T any](tree any, filter FilterFunc, opts ...Option) (T, error) {
+	node, err := NewWalker(opts...).findNode(tree, FilterAs[T](filter))
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	v, _ := valueAs[T](node)
+	return v, nil
+}
+
+// TraverseAs searches for all values of type T that match the filter, as
+// FindAs does, without descending into matched values. Returns a slice of
+// matching values and an error if none found.
+func TraverseAs[T any](tree any, filter FilterFunc, opts ...Option) ([]T, error) {
+	nodes, err := NewWalker(opts...).traverseNodes(tree, FilterAs[T](filter), SkipChildren)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]T, len(nodes))
+	for i, n := range nodes {
+		values[i], _ = valueAs[T](n)
+	}
+	return values, nil
+}
+
+// HasAs reports whether any value of type T matches the filter, as FindAs
+// does.
+func HasAs[T any](tree any, filter FilterFunc, opts ...Option) bool {
+	_, err := NewWalker(opts...).findNode(tree, FilterAs[T](filter))
+	return err == nil
+}
+
+// FilterAs returns a FilterFunc that checks if a node's value is of type T, as
+// described for FindAs, and satisfies the provided filter condition.
+func FilterAs[T any](filter FilterFunc) FilterFunc {
+	return func(n Node) bool {
+		_, ok := valueAs[T](n)
+		return ok && filter(n)
+	}
+}
+
+// valueAs returns the value of n as a T, trying the raw value before the
+// resolved one.
+func valueAs[T any](n Node) (T, bool) {
+	if v, ok := n.Interface.(T); ok {
+		return v, true
+	}
+	if n.Value.IsValid() && n.Value.CanInterface() {
+		if v, ok := n.Value.Interface().(T); ok {
+			return v, true
+		}
+	}
+
+	var zero T
+	return zero, false
+}
@@ -0,0 +1,90 @@
+package gotree
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testLevel string
+
+type testEvent struct {
+	Name    string
+	Level   testLevel
+	At      time.Time
+	Updated *time.Time
+	Payload []byte
+	Tags    []string
+}
+
+func TestGenericFunctions(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	events := []testEvent{
+		{Name: "start", Level: "info", At: day, Payload: []byte("a"), Tags: []string{"x"}},
+		{Name: "stop", Level: "warn", At: day.Add(time.Hour), Updated: ptr(day.Add(2 * time.Hour))},
+	}
+
+	t.Run("TestFindAs", func(t *testing.T) {
+		level, err := FindAs[testLevel](events, ValueEquals(testLevel("warn")))
+		if err != nil || level != "warn" {
+			t.Errorf("FindAs[testLevel]() = (%v, %v), want warn", level, err)
+		}
+
+		// A named string type is not a string
+		if s, err := FindAs[string](events, KeyFilter("Level")); err == nil {
+			t.Errorf("FindAs[string]() = %q, want an error", s)
+		}
+
+		payload, err := FindAs[[]byte](events, NoneFilter)
+		if err != nil || string(payload) != "a" {
+			t.Errorf("FindAs[[]byte]() = (%v, %v), want a", payload, err)
+		}
+
+		updated, err := FindAs[*time.Time](events, FilterNil(NoneFilter))
+		if err != nil || updated != nil {
+			t.Errorf("FindAs[*time.Time]() = (%v, %v), want nil", updated, err)
+		}
+
+		if _, err := FindAs[int](nil, NoneFilter); err != ErrNilTree {
+			t.Errorf("FindAs[int](nil) error = %v, want %v", err, ErrNilTree)
+		}
+	})
+
+	t.Run("TestTraverseAs", func(t *testing.T) {
+		times, err := TraverseAs[time.Time](events, NoneFilter)
+		want := []time.Time{day, day.Add(time.Hour), day.Add(2 * time.Hour)}
+		if err != nil || !reflect.DeepEqual(times, want) {
+			t.Errorf("TraverseAs[time.Time]() = (%v, %v), want %v", times, err, want)
+		}
+
+		tags, err := TraverseAs[[]string](events, NoneFilter)
+		if err != nil || len(tags) != 2 || tags[0][0] != "x" || tags[1] != nil {
+			t.Errorf("TraverseAs[[]string]() = (%v, %v), want [[x] []]", tags, err)
+		}
+
+		if _, err := TraverseAs[float64](events, NoneFilter); err != ErrNotFound {
+			t.Errorf("TraverseAs[float64]() error = %v, want %v", err, ErrNotFound)
+		}
+	})
+
+	t.Run("TestTraverseAsInterface", func(t *testing.T) {
+		// Both At and the nil Updated pointer implement fmt.Stringer
+		got, err := TraverseAs[fmt.Stringer](events[0], NoneFilter)
+		if err != nil || len(got) != 2 || got[0].String() != day.String() {
+			t.Fatalf("TraverseAs[fmt.Stringer]() = (%v, %v), want [%v <nil>]", got, err, day)
+		}
+		if updated, ok := got[1].(*time.Time); !ok || updated != nil {
+			t.Errorf("TraverseAs[fmt.Stringer]()[1] = %#v, want a nil *time.Time", got[1])
+		}
+	})
+
+	t.Run("TestHasAs", func(t *testing.T) {
+		if !HasAs[time.Time](events, TimeAfter(day)) {
+			t.Errorf("HasAs[time.Time]() = false, want true")
+		}
+		if HasAs[int](events, NoneFilter) {
+			t.Errorf("HasAs[int]() = true, want false")
+		}
+	})
+}
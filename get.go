@@ -0,0 +1,188 @@
+package gotree
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// lookup descends from the root of tree along path and returns the node it
+// ends at. Only the containers on the path are inspected, so the cost grows
+// with the length of the path rather than the size of the tree. A missing
+// segment is reported as a *PathError wrapping ErrNotFound, naming the path
+// up to and including that segment.
+func lookup(tree any, path Path) (Node, error) {
+	if tree == nil {
+		return Node{}, ErrNilTree
+	}
+
+	node := newNode("", "", reflect.ValueOf(tree))
+	for i, seg := range path {
+		// Every step needs its own parent for the Parent chain
+		parent := node
+		child, ok := childAt(&parent, seg)
+		if !ok {
+			return Node{}, &PathError{
+				FullKey: path[:i+1].String(),
+				Err:     ErrNotFound,
+			}
+		}
+		node = child
+	}
+
+	return node, nil
+}
+
+// childAt returns the child of parent addressed by seg. Key and field
+// segments are accepted for both maps and structs, and map keys that are not
+// strings are matched by their formatted form. Keys made of digits also
+// select elements of slices and arrays, as JSON Pointer tokens do.
+func childAt(parent *Node, seg Segment) (Node, bool) {
+	if parent.IsNil() {
+		return Node{}, false
+	}
+	value := parent.Value
+
+	switch value.Kind() {
+	case reflect.Map:
+		key := seg.Key
+		if seg.Kind == IndexSegment {
+			key = strconv.Itoa(seg.Index)
+		}
+
+		keyType := value.Type().Key()
+		if keyType.Kind() == reflect.String {
+			k := reflect.ValueOf(key).Convert(keyType)
+			if v := value.MapIndex(k); v.IsValid() {
+				return parent.mapChild(k, v), true
+			}
+			return Node{}, false
+		}
+
+		iter := value.MapRange()
+		for iter.Next() {
+			if fmt.Sprint(iter.Key().Interface()) == key {
+				return parent.mapChild(iter.Key(), iter.Value()), true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		index := seg.Index
+		if seg.Kind != IndexSegment {
+			var ok bool
+			if index, ok = arrayIndex(seg.Key); !ok {
+				return Node{}, false
+			}
+		}
+
+		if index < 0 || index >= value.Len() {
+			return Node{}, false
+		}
+		return parent.indexChild(index, value.Index(index)), true
+	case reflect.Struct:
+		if seg.Kind == IndexSegment {
+			return Node{}, false
+		}
+
+		// Promoted fields are not children of the struct for the walker
+		// either, so only direct fields are looked up
+		field, ok := value.Type().FieldByName(seg.Key)
+		if !ok || len(field.Index) != 1 || !field.IsExported() {
+			return Node{}, false
+		}
+		return parent.fieldChild(field.Name, value.Field(field.Index[0])), true
+	}
+
+	return Node{}, false
+}
+
+// getNode parses path and looks it up in tree.
+func getNode(tree any, path string) (Node, error) {
+	p, err := ParsePath(path)
+	if err != nil {
+		return Node{}, err
+	}
+	return lookup(tree, p)
+}
+
+// getTyped looks up path in tree and checks the value there with the kind
+// filter typeFilter, e.g. FilterString.
+func getTyped(tree any, path string, typeFilter func(FilterFunc) FilterFunc) (Node, error) {
+	node, err := getNode(tree, path)
+	if err != nil {
+		return Node{}, err
+	}
+	if !typeFilter(NoneFilter)(node) {
+		return Node{}, &PathError{FullKey: path, Err: ErrType}
+	}
+	return node, nil
+}
+
+// Get returns the value at path in tree. The path is written the way
+// Path.String writes it, e.g. users[0].name or config["log.level"], and
+// only the containers along it are inspected.
+//
+// Get returns ErrNilTree if tree is nil, a *SyntaxError if path is malformed
+// and a *PathError wrapping ErrNotFound for the first segment that does not
+// exist.
+func Get(tree any, path string) (any, error) {
+	node, err := getNode(tree, path)
+	if err != nil {
+		return nil, err
+	}
+	return node.Interface, nil
+}
+
+// GetString returns the string at path in tree. It returns a *PathError
+// wrapping ErrType if the value there is not a string, and otherwise the same
+// errors as Get.
+func GetString(tree any, path string) (string, error) {
+	node, err := getTyped(tree, path, FilterString)
+	if err != nil {
+		return "", err
+	}
+	return node.Value.String(), nil
+}
+
+// GetBool returns the bool at path in tree. It returns a *PathError wrapping
+// ErrType if the value there is not a bool, and otherwise the same errors as
+// Get.
+func GetBool(tree any, path string) (bool, error) {
+	node, err := getTyped(tree, path, FilterBool)
+	if err != nil {
+		return false, err
+	}
+	return node.Value.Bool(), nil
+}
+
+// GetInt returns the signed integer at path in tree as an int64. It returns a
+// *PathError wrapping ErrType if the value there is not a signed integer, and
+// otherwise the same errors as Get.
+func GetInt(tree any, path string) (int64, error) {
+	node, err := getTyped(tree, path, FilterInt)
+	if err != nil {
+		return 0, err
+	}
+	return node.Value.Int(), nil
+}
+
+// GetUint returns the unsigned integer at path in tree as a uint64. It
+// returns a *PathError wrapping ErrType if the value there is not an unsigned
+// integer, and otherwise the same errors as Get.
+func GetUint(tree any, path string) (uint64, error) {
+	node, err := getTyped(tree, path, FilterUint)
+	if err != nil {
+		return 0, err
+	}
+	return node.Value.Uint(), nil
+}
+
+// GetFloat returns the floating point number at path in tree as a float64.
+// It returns a *PathError wrapping ErrType if the value there is not a float,
+// and otherwise the same errors as Get.
+func GetFloat(tree any, path string) (float64, error) {
+	node, err := getTyped(tree, path, FilterFloat)
+	if err != nil {
+		return 0, err
+	}
+	return node.Value.Float(), nil
+}
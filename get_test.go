@@ -0,0 +1,141 @@
+package gotree
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGetFunctions(t *testing.T) {
+	data := map[string]any{
+		"user": map[string]any{
+			"name":   "Ephemeral",
+			"age":    30,
+			"admin":  true,
+			"id":     uint64(7),
+			"rating": 4.5,
+		},
+		"config": map[string]string{"log.level": "debug"},
+		"codes":  map[int]string{404: "not found"},
+		"people": []*testUser{{Name: "Alice", Age: ptr(30)}},
+		"empty":  nil,
+	}
+
+	t.Run("TestGet", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			path    string
+			want    any
+			wantErr error
+		}{
+			{
+				name: "Nested map",
+				path: "user.name",
+				want: "Ephemeral",
+			},
+			{
+				name: "Quoted key",
+				path: `config["log.level"]`,
+				want: "debug",
+			},
+			{
+				name: "Non-string map key",
+				path: "codes.404",
+				want: "not found",
+			},
+			{
+				name: "Slice of struct pointers",
+				path: "people[0].Name",
+				want: "Alice",
+			},
+			{
+				name: "Pointer field",
+				path: "people[0].Age",
+				want: ptr(30),
+			},
+			{
+				name: "Root",
+				path: "",
+				want: data,
+			},
+			{
+				name:    "Missing key",
+				path:    "user.email",
+				wantErr: ErrNotFound,
+			},
+			{
+				name:    "Index out of range",
+				path:    "people[1].Name",
+				wantErr: ErrNotFound,
+			},
+			{
+				name:    "Below nil",
+				path:    "empty.x",
+				wantErr: ErrNotFound,
+			},
+			{
+				name:    "Unexported or missing field",
+				path:    "people[0].name",
+				wantErr: ErrNotFound,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := Get(data, tt.path)
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Get() error = %v, want %v", err, tt.wantErr)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Get() = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("TestGetErrors", func(t *testing.T) {
+		_, err := Get(data, "people[0].Manager.Name")
+		var pathErr *PathError
+		if !errors.As(err, &pathErr) || pathErr.FullKey != "people[0].Manager.Name" {
+			t.Errorf("Get() error = %v, want missing people[0].Manager.Name", err)
+		}
+
+		_, err = Get(data, "user.missing.deeper")
+		if !errors.As(err, &pathErr) || pathErr.FullKey != "user.missing" {
+			t.Errorf("Get() error = %v, want missing user.missing", err)
+		}
+
+		var syntaxErr *SyntaxError
+		if _, err := Get(data, "user..name"); !errors.As(err, &syntaxErr) {
+			t.Errorf("Get() error = %v, want *SyntaxError", err)
+		}
+
+		if _, err := Get(nil, "user"); err != ErrNilTree {
+			t.Errorf("Get(nil) error = %v, want %v", err, ErrNilTree)
+		}
+	})
+
+	t.Run("TestGetTyped", func(t *testing.T) {
+		if got, err := GetString(data, "user.name"); got != "Ephemeral" || err != nil {
+			t.Errorf("GetString() = (%v, %v), want Ephemeral", got, err)
+		}
+		if got, err := GetInt(data, "people[0].Age"); got != 30 || err != nil {
+			t.Errorf("GetInt() = (%v, %v), want 30", got, err)
+		}
+		if got, err := GetBool(data, "user.admin"); !got || err != nil {
+			t.Errorf("GetBool() = (%v, %v), want true", got, err)
+		}
+		if got, err := GetUint(data, "user.id"); got != 7 || err != nil {
+			t.Errorf("GetUint() = (%v, %v), want 7", got, err)
+		}
+		if got, err := GetFloat(data, "user.rating"); got != 4.5 || err != nil {
+			t.Errorf("GetFloat() = (%v, %v), want 4.5", got, err)
+		}
+		if _, err := GetString(data, "user.age"); !errors.Is(err, ErrType) {
+			t.Errorf("GetString() error = %v, want %v", err, ErrType)
+		}
+		if _, err := GetInt(data, "user.nope"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetInt() error = %v, want %v", err, ErrNotFound)
+		}
+	})
+}
@@ -485,4 +485,59 @@ func TestHasFunctions(t *testing.T) {
 			})
 		}
 	})
+	t.Run("TestHasPointers", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			tree   any
+			filter func(Node) bool
+			want   bool
+		}{
+			{
+				name: "Has string behind pointer",
+				tree: pointerData,
+				filter: func(n Node) bool {
+					return n.Value.Kind() == reflect.String &&
+						n.Value.String() == "Paris"
+				},
+				want: true,
+			},
+			{
+				name: "Has nil pointer",
+				tree: pointerData,
+				filter: func(n Node) bool {
+					return n.Key == "Manager" && n.Value.IsNil()
+				},
+				want: true,
+			},
+			{
+				name: "Has field below nil pointer",
+				tree: pointerData,
+				filter: func(n Node) bool {
+					return n.FullKey == "Owner.Manager.Name"
+				},
+				want: false,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got := Has(tt.tree, tt.filter)
+				if got != tt.want {
+					t.Errorf("Has() = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("TestHasContainers", func(t *testing.T) {
+		if !HasMap(testData, KeyFilter("nested")) {
+			t.Errorf("HasMap() = false, want true")
+		}
+		if HasMap(testData, KeyFilter("array")) {
+			t.Errorf("HasMap() = true, want false")
+		}
+		if !HasSlice(testData, KeyFilter("array")) {
+			t.Errorf("HasSlice() = false, want true")
+		}
+	})
 }
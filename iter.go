@@ -0,0 +1,59 @@
+package gotree
+
+// All returns an iterator over every node of tree in the order set by
+// WithOrder, starting with the root itself unless the order is PostOrder.
+// Nodes are produced one at a time while the tree is walked, and the walk
+// ends as soon as yield returns false, so large trees are never collected
+// into a slice.
+//
+// With Go 1.23 or later the iterator can be ranged over:
+//
+//	for n := range w.All(tree) {
+//		if n.Key == "id" {
+//			break
+//		}
+//	}
+//
+// With older toolchains, call it with the loop body instead:
+//
+//	w.All(tree)(func(n Node) bool {
+//		return n.Key != "id"
+//	})
+//
+// An iterator can't report errors: a nil tree yields nothing, and with
+// CycleError the iteration ends at the first cycle. Use Visit or Walk when
+// those errors matter.
+func (w *Walker) All(tree any) func(yield func(Node) bool) {
+	return func(yield func(Node) bool) {
+		w.Walk(tree, yield)
+	}
+}
+
+// All returns an iterator over every node of tree in the order set by
+// WithOrder. It is a shorthand for NewWalker(opts...).All(tree).
+func All(tree any, opts ...Option) func(yield func(Node) bool) {
+	return NewWalker(opts...).All(tree)
+}
+
+// Matches returns an iterator over the nodes of tree accepted by filter. Like
+// Traverse, it does not descend into a matched node, but it produces the
+// matches lazily and stops walking as soon as yield returns false.
+func (w *Walker) Matches(tree any, filter FilterFunc) func(yield func(Node) bool) {
+	return func(yield func(Node) bool) {
+		w.Visit(tree, func(n Node) Action {
+			if !test(n, filter) || w.nestedMatch(n, filter, SkipChildren) {
+				return Continue
+			}
+			if !yield(n) {
+				return Stop
+			}
+			return SkipChildren
+		})
+	}
+}
+
+// Matches returns an iterator over the nodes of tree accepted by filter. It is
+// a shorthand for NewWalker(opts...).Matches(tree, filter).
+func Matches(tree any, filter FilterFunc, opts ...Option) func(yield func(Node) bool) {
+	return NewWalker(opts...).Matches(tree, filter)
+}
@@ -0,0 +1,40 @@
+//go:build go1.23
+
+package gotree
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRangeOverFunc(t *testing.T) {
+	data := []any{
+		map[string]any{"id": 1},
+		map[string]any{"id": 2},
+		map[string]any{"id": 3},
+	}
+
+	var ids []any
+	for n := range All(data) {
+		if n.Key != "id" {
+			continue
+		}
+		ids = append(ids, n.Interface)
+		if len(ids) == 2 {
+			break
+		}
+	}
+
+	want := []any{1, 2}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("range All() = %v, want %v", ids, want)
+	}
+
+	count := 0
+	for range Matches(data, KeyFilter("id")) {
+		count++
+	}
+	if count != 3 {
+		t.Errorf("range Matches() = %d nodes, want 3", count)
+	}
+}
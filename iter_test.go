@@ -0,0 +1,57 @@
+package gotree
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIterators(t *testing.T) {
+	data := map[string]any{
+		"a": []any{1, 2, 3},
+		"b": map[string]any{"c": "x", "d": "y"},
+	}
+
+	t.Run("TestAll", func(t *testing.T) {
+		var got []string
+		All(data, WithKeyOrder(LexicalOrder))(func(n Node) bool {
+			got = append(got, n.FullKey)
+			return true
+		})
+
+		want := []string{"", "a", "a[0]", "a[1]", "a[2]", "b", "b.c", "b.d"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("All() yielded %v, want %v", got, want)
+		}
+	})
+
+	t.Run("TestAllStops", func(t *testing.T) {
+		calls := 0
+		All(data, WithKeyOrder(LexicalOrder))(func(n Node) bool {
+			calls++
+			return n.FullKey != "a[0]"
+		})
+		if calls != 3 {
+			t.Errorf("yield called %d times, want 3", calls)
+		}
+	})
+
+	t.Run("TestAllNil", func(t *testing.T) {
+		All(nil)(func(n Node) bool {
+			t.Errorf("All(nil) yielded %v", n)
+			return true
+		})
+	})
+
+	t.Run("TestMatches", func(t *testing.T) {
+		var got []any
+		Matches(data, FilterString(NoneFilter), WithKeyOrder(LexicalOrder))(func(n Node) bool {
+			got = append(got, n.Interface)
+			return len(got) < 1
+		})
+
+		want := []any{"x"}
+		if !EqualSlices(t, want, got) {
+			t.Errorf("Matches() yielded %v, want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,848 @@
+package gotree
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+)
+
+// Query evaluates the JSONPath expression expr against tree and returns the
+// matched nodes. Maps and structs are both treated as objects, and slices and
+// arrays as arrays, so Go values can be queried just like decoded JSON. Map
+// entries are visited in the order set by WithKeyOrder.
+//
+// The supported syntax covers:
+//
+//   - the root $ and, inside filters, the current node @
+//   - child access: $.store.book, $['store']['book'] and $.store.*
+//   - recursive descent: $..author, $..*, $..[0]
+//   - indexes and slices: [0], [-1], [1:3], [::2]
+//   - unions: [0,2], ['title','price']
+//   - filters: [?(@.price < 10 && @.category == 'fiction')], with ==, !=,
+//     <, <=, >, >=, =~ (regular expression match), !~, &&, || and !, and
+//     existence tests such as [?(@.isbn)]
+//
+// Only KeyOrder and Cycle of opts are used. Query returns ErrNilTree if tree
+// is nil, a *SyntaxError if expr is malformed and ErrNotFound if nothing
+// matches.
+func Query(tree any, expr string, opts ...Option) ([]Node, error) {
+	q, err := compileJSONPath(expr)
+	if err != nil {
+		return nil, err
+	}
+	return q.query(tree, newOptions(opts))
+}
+
+// jsonPath is a compiled JSONPath expression.
+type jsonPath struct {
+	segments []jpSegment
+}
+
+// jpSegment is one step of a JSONPath: the selectors of a child segment, or of
+// a descendant segment when written after "..".
+type jpSegment struct {
+	descendant bool
+	selectors  []jpSelector
+}
+
+// jpSelector selects children of a node.
+type jpSelector interface {
+	// apply appends the children of node selected by the selector to out.
+	apply(ctx *jpContext, node Node, out []Node) []Node
+}
+
+// jpContext carries the root of the tree and the walk state during an
+// evaluation. The state provides the options and records the first error, but
+// the descendant segments are walked with states of their own.
+type jpContext struct {
+	root Node
+	s    *walkState
+}
+
+// query runs q against tree.
+func (q *jsonPath) query(tree any, opts Options) ([]Node, error) {
+	var nodes []Node
+	err := q.run(tree, opts, func(n Node) bool {
+		nodes = append(nodes, n)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, ErrNotFound
+	}
+	return nodes, nil
+}
+
+// first returns the first node that query would return, without evaluating q
+// any further.
+func (q *jsonPath) first(tree any, opts Options) (Node, error) {
+	var nodes []Node
+	err := q.run(tree, opts, func(n Node) bool {
+		nodes = append(nodes, n)
+		return false
+	})
+	if err != nil {
+		return Node{}, err
+	}
+	if len(nodes) == 0 {
+		return Node{}, ErrNotFound
+	}
+	return nodes[0], nil
+}
+
+// run calls yield for every node selected by q in tree, until yield returns
+// false.
+func (q *jsonPath) run(tree any, opts Options, yield func(Node) bool) error {
+	if tree == nil {
+		return ErrNilTree
+	}
+
+	// Depth limits would cut recursive descent short, so only the map order
+	// and cycle handling are kept
+	opts = Options{Cycle: opts.Cycle, KeyOrder: opts.KeyOrder}
+	ctx := &jpContext{
+		root: newNode("", "", reflect.ValueOf(tree)),
+		s:    newWalkState(opts),
+	}
+
+	q.each(ctx, ctx.root, yield)
+	return ctx.s.err
+}
+
+// each calls yield for every node selected by q from start, until yield
+// returns false. The segments are applied depth-first, one node at a time, so
+// nothing more of the tree is walked once yield returns false. Errors
+// are recorded in ctx.s.err, which is not walked itself.
+func (q *jsonPath) each(ctx *jpContext, start Node, yield func(Node) bool) {
+	q.eachFrom(ctx, make([]*walkState, len(q.segments)), 0, start, yield)
+}
+
+// eachFrom applies the segments of q from the i-th on to node, walking the
+// descendant segments with the states in walks. It reports whether to go on.
+func (q *jsonPath) eachFrom(ctx *jpContext, walks []*walkState, i int, node Node, yield func(Node) bool) bool {
+	if i == len(q.segments) {
+		return yield(node)
+	}
+
+	seg := q.segments[i]
+	apply := func(n Node) bool {
+		for _, sel := range seg.selectors {
+			for _, child := range sel.apply(ctx, n, nil) {
+				if ctx.s.err != nil || !q.eachFrom(ctx, walks, i+1, child, yield) {
+					return false
+				}
+			}
+		}
+		return ctx.s.err == nil
+	}
+	if !seg.descendant {
+		return apply(node)
+	}
+
+	// Every descendant segment has a walk state of its own, or the nodes
+	// being walked by one would look like cycles to those after it
+	if walks[i] == nil {
+		walks[i] = newWalkState(ctx.s.opts)
+	}
+	s := walks[i]
+
+	more := true
+	s.walk(node, func(n Node) Action {
+		if more = apply(n); !more {
+			return Stop
+		}
+		return Continue
+	})
+	if s.err != nil {
+		if ctx.s.err == nil {
+			ctx.s.err = s.err
+		}
+		return false
+	}
+	return more
+}
+
+// nameSelector selects a map entry or struct field by name.
+type nameSelector struct {
+	name string
+}
+
+func (sel nameSelector) apply(ctx *jpContext, node Node, out []Node) []Node {
+	seg := Segment{Kind: KeySegment, Key: sel.name}
+	if child, ok := childAt(&node, seg); ok {
+		out = append(out, child)
+	}
+	return out
+}
+
+// wildcardSelector selects every child.
+type wildcardSelector struct{}
+
+func (wildcardSelector) apply(ctx *jpContext, node Node, out []Node) []Node {
+	ctx.s.eachChild(node, func(child Node) bool {
+		out = append(out, child)
+		return true
+	})
+	return out
+}
+
+// indexSelector selects an array element, counting from the end when
+// negative.
+type indexSelector struct {
+	index int
+}
+
+func (sel indexSelector) apply(ctx *jpContext, node Node, out []Node) []Node {
+	if !isArray(node) {
+		return out
+	}
+
+	index := sel.index
+	if index < 0 {
+		index += node.Value.Len()
+	}
+	if index < 0 || index >= node.Value.Len() {
+		return out
+	}
+	return append(out, node.indexChild(index, node.Value.Index(index)))
+}
+
+// sliceSelector selects a range of array elements as [start:end:step]. nil
+// bounds take their defaults.
+type sliceSelector struct {
+	start, end *int
+	step       int
+}
+
+func (sel sliceSelector) apply(ctx *jpContext, node Node, out []Node) []Node {
+	if !isArray(node) || sel.step == 0 {
+		return out
+	}
+
+	n := node.Value.Len()
+	bound := func(i *int, def int) int {
+		if i == nil {
+			return def
+		}
+		if *i < 0 {
+			return *i + n
+		}
+		return *i
+	}
+	clamp := func(i, lo, hi int) int {
+		if i < lo {
+			return lo
+		}
+		if i > hi {
+			return hi
+		}
+		return i
+	}
+
+	if sel.step > 0 {
+		lower := clamp(bound(sel.start, 0), 0, n)
+		upper := clamp(bound(sel.end, n), 0, n)
+		for i := lower; i < upper; i += sel.step {
+			out = append(out, node.indexChild(i, node.Value.Index(i)))
+			// Stop before a huge step overflows i
+			if sel.step >= upper-i {
+				break
+			}
+		}
+		return out
+	}
+
+	upper := clamp(bound(sel.start, n-1), -1, n-1)
+	lower := clamp(bound(sel.end, -n-1), -1, n-1)
+	for i := upper; i > lower; i += sel.step {
+		out = append(out, node.indexChild(i, node.Value.Index(i)))
+		if sel.step <= lower-i {
+			break
+		}
+	}
+	return out
+}
+
+// isArray reports whether node holds a slice or array.
+func isArray(node Node) bool {
+	kind := node.Value.Kind()
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
+// filterSelector selects the children for which expr holds.
+type filterSelector struct {
+	expr jpExpr
+}
+
+func (sel filterSelector) apply(ctx *jpContext, node Node, out []Node) []Node {
+	ctx.s.eachChild(node, func(child Node) bool {
+		if sel.expr.test(ctx, child) {
+			out = append(out, child)
+		}
+		return true
+	})
+	return out
+}
+
+// jpExpr is a boolean filter expression evaluated with @ bound to cur.
+type jpExpr interface {
+	test(ctx *jpContext, cur Node) bool
+}
+
+type jpOr struct{ left, right jpExpr }
+
+func (e jpOr) test(ctx *jpContext, cur Node) bool {
+	return e.left.test(ctx, cur) || e.right.test(ctx, cur)
+}
+
+type jpAnd struct{ left, right jpExpr }
+
+func (e jpAnd) test(ctx *jpContext, cur Node) bool {
+	return e.left.test(ctx, cur) && e.right.test(ctx, cur)
+}
+
+type jpNot struct{ expr jpExpr }
+
+func (e jpNot) test(ctx *jpContext, cur Node) bool {
+	return !e.expr.test(ctx, cur)
+}
+
+// jpExists holds when its query selects at least one node.
+type jpExists struct{ query jpQuery }
+
+func (e jpExists) test(ctx *jpContext, cur Node) bool {
+	found := false
+	e.query.each(ctx, cur, func(Node) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// jpCompare compares two operands. For =~ and !~ the right operand is a
+// regular expression compiled while parsing.
+type jpCompare struct {
+	op          string
+	left, right jpOperand
+	re          *regexp.Regexp
+}
+
+func (e jpCompare) test(ctx *jpContext, cur Node) bool {
+	left := e.left.value(ctx, cur)
+	if e.re != nil {
+		matched := left.kind == jpString && e.re.MatchString(left.str)
+		return matched == (e.op == "=~")
+	}
+
+	right := e.right.value(ctx, cur)
+	switch e.op {
+	case "==":
+		return left.equal(right)
+	case "!=":
+		return !left.equal(right)
+	case "<":
+		return left.less(right)
+	case "<=":
+		return left.less(right) || left.equal(right)
+	case ">":
+		return right.less(left)
+	case ">=":
+		return right.less(left) || left.equal(right)
+	}
+	return false
+}
+
+// jpOperand is a side of a comparison.
+type jpOperand interface {
+	value(ctx *jpContext, cur Node) jpValue
+}
+
+// jpQuery is a query inside a filter, relative to @ or absolute from $.
+type jpQuery struct {
+	absolute bool
+	path     *jsonPath
+}
+
+// each calls yield for the nodes selected by q from cur, until yield returns
+// false.
+func (q jpQuery) each(ctx *jpContext, cur Node, yield func(Node) bool) {
+	if q.absolute {
+		cur = ctx.root
+	}
+	q.path.each(ctx, cur, yield)
+}
+
+// value returns the value of the single node selected by q. Selecting no node,
+// or more than one, yields nothing.
+func (q jpQuery) value(ctx *jpContext, cur Node) jpValue {
+	var nodes []Node
+	q.each(ctx, cur, func(n Node) bool {
+		nodes = append(nodes, n)
+		return len(nodes) < 2
+	})
+	if len(nodes) != 1 {
+		return jpValue{}
+	}
+	return valueOfNode(nodes[0])
+}
+
+// jpLiteral is a constant operand.
+type jpLiteral struct{ v jpValue }
+
+func (l jpLiteral) value(*jpContext, Node) jpValue {
+	return l.v
+}
+
+// jpValueKind is the JSON type of a jpValue.
+type jpValueKind int
+
+const (
+	jpNothing jpValueKind = iota
+	jpNull
+	jpBool
+	jpNumber
+	jpString
+	jpOther
+)
+
+// jpValue is an operand value in a filter comparison.
+type jpValue struct {
+	kind jpValueKind
+	b    bool
+	num  float64
+	str  string
+
+	// other holds maps, slices and structs, compared deeply
+	other any
+}
+
+// valueOfNode converts the value of node for comparisons. Every integer and
+// float kind becomes a number.
+func valueOfNode(node Node) jpValue {
+	if node.IsNil() {
+		return jpValue{kind: jpNull}
+	}
+
+	v := node.Value
+	switch {
+	case v.Kind() == reflect.Bool:
+		return jpValue{kind: jpBool, b: v.Bool()}
+	case v.Kind() == reflect.String:
+		return jpValue{kind: jpString, str: v.String()}
+	case isInt(v):
+		return jpValue{kind: jpNumber, num: float64(v.Int())}
+	case isUint(v):
+		return jpValue{kind: jpNumber, num: float64(v.Uint())}
+	case isFloat(v):
+		return jpValue{kind: jpNumber, num: v.Float()}
+	default:
+		return jpValue{kind: jpOther, other: node.Interface}
+	}
+}
+
+func (v jpValue) equal(o jpValue) bool {
+	if v.kind != o.kind {
+		return false
+	}
+	switch v.kind {
+	case jpBool:
+		return v.b == o.b
+	case jpNumber:
+		return v.num == o.num
+	case jpString:
+		return v.str == o.str
+	case jpOther:
+		return reflect.DeepEqual(v.other, o.other)
+	default:
+		// Two nulls, or nothing on both sides
+		return true
+	}
+}
+
+// less orders numbers and strings. Values of other kinds are never less than
+// each other.
+func (v jpValue) less(o jpValue) bool {
+	if v.kind != o.kind {
+		return false
+	}
+	switch v.kind {
+	case jpNumber:
+		return v.num < o.num
+	case jpString:
+		return v.str < o.str
+	default:
+		return false
+	}
+}
+
+// compileJSONPath parses a JSONPath expression.
+func compileJSONPath(expr string) (*jsonPath, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &jpParser{input: expr, tokens: tokens}
+	if !p.accept("$") {
+		return nil, p.errorf("expected '$' at the start of a JSONPath")
+	}
+
+	q, err := p.segments()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokEOF {
+		return nil, p.errorf("unexpected %q", p.peek().text)
+	}
+	return q, nil
+}
+
+// jpParser builds a jsonPath from tokens. It also parses the expressions of
+// ParseFilter, whose operands are properties of the node instead of queries.
+type jpParser struct {
+	input  string
+	tokens []token
+	pos    int
+
+	// filter is set when parsing a ParseFilter expression.
+	filter bool
+}
+
+func (p *jpParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *jpParser) advance() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is the punctuation punct.
+func (p *jpParser) accept(punct string) bool {
+	if tok := p.peek(); tok.kind == tokPunct && tok.text == punct {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *jpParser) expect(punct string) error {
+	if !p.accept(punct) {
+		return p.errorf("expected %q", punct)
+	}
+	return nil
+}
+
+// errorf reports a syntax error at the next token.
+func (p *jpParser) errorf(format string, args ...any) error {
+	return &SyntaxError{
+		Input:  p.input,
+		Offset: p.peek().pos,
+		Msg:    fmt.Sprintf(format, args...),
+	}
+}
+
+// segments parses child and descendant segments for as long as they follow.
+func (p *jpParser) segments() (*jsonPath, error) {
+	q := &jsonPath{}
+	for {
+		var seg jpSegment
+		switch {
+		case p.accept(".."):
+			seg.descendant = true
+			if p.peek().text == "[" && p.peek().kind == tokPunct {
+				sels, err := p.bracket()
+				if err != nil {
+					return nil, err
+				}
+				seg.selectors = sels
+			} else {
+				sel, err := p.dotSelector()
+				if err != nil {
+					return nil, err
+				}
+				seg.selectors = []jpSelector{sel}
+			}
+		case p.accept("."):
+			sel, err := p.dotSelector()
+			if err != nil {
+				return nil, err
+			}
+			seg.selectors = []jpSelector{sel}
+		case p.peek().kind == tokPunct && p.peek().text == "[":
+			sels, err := p.bracket()
+			if err != nil {
+				return nil, err
+			}
+			seg.selectors = sels
+		default:
+			return q, nil
+		}
+		q.segments = append(q.segments, seg)
+	}
+}
+
+// dotSelector parses the name or * after a dot.
+func (p *jpParser) dotSelector() (jpSelector, error) {
+	if p.accept("*") {
+		return wildcardSelector{}, nil
+	}
+
+	tok := p.peek()
+	if tok.kind != tokIdent && tok.kind != tokNumber {
+		return nil, p.errorf("expected name or '*'")
+	}
+	p.advance()
+	return nameSelector{name: tok.text}, nil
+}
+
+// bracket parses a bracketed, comma separated list of selectors.
+func (p *jpParser) bracket() ([]jpSelector, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+
+	var sels []jpSelector
+	for {
+		sel, err := p.selector()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+
+		if p.accept("]") {
+			return sels, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// selector parses a single selector inside brackets.
+func (p *jpParser) selector() (jpSelector, error) {
+	tok := p.peek()
+	switch {
+	case tok.kind == tokString:
+		p.advance()
+		return nameSelector{name: tok.text}, nil
+	case p.accept("*"):
+		return wildcardSelector{}, nil
+	case p.accept("?"):
+		expr, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		return filterSelector{expr: expr}, nil
+	case tok.kind == tokNumber || (tok.kind == tokPunct && tok.text == ":"):
+		return p.indexOrSlice()
+	}
+	return nil, p.errorf("expected selector")
+}
+
+// indexOrSlice parses [index] or [start:end:step].
+func (p *jpParser) indexOrSlice() (jpSelector, error) {
+	start, err := p.optionalInt()
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept(":") {
+		if start == nil {
+			return nil, p.errorf("expected index")
+		}
+		return indexSelector{index: *start}, nil
+	}
+
+	sel := sliceSelector{start: start, step: 1}
+	if sel.end, err = p.optionalInt(); err != nil {
+		return nil, err
+	}
+	if p.accept(":") {
+		step, err := p.optionalInt()
+		if err != nil {
+			return nil, err
+		}
+		if step != nil {
+			sel.step = *step
+		}
+	}
+	return sel, nil
+}
+
+// optionalInt parses an integer if one follows.
+func (p *jpParser) optionalInt() (*int, error) {
+	tok := p.peek()
+	if tok.kind != tokNumber {
+		return nil, nil
+	}
+
+	i, err := strconv.Atoi(tok.text)
+	if err != nil {
+		return nil, p.errorf("expected integer")
+	}
+	p.advance()
+	return &i, nil
+}
+
+// or parses expr || expr.
+func (p *jpParser) or() (jpExpr, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = jpOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+// and parses expr && expr.
+func (p *jpParser) and() (jpExpr, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = jpAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+// unary parses a negation, a parenthesized expression, an existence test or a
+// comparison.
+func (p *jpParser) unary() (jpExpr, error) {
+	if p.accept("!") {
+		expr, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return jpNot{expr: expr}, nil
+	}
+
+	if p.accept("(") {
+		expr, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+
+	leftTok := p.peek()
+	left, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+
+	opTok := p.peek()
+	switch opTok.text {
+	case "==", "!=", "<", "<=", ">", ">=", "=~", "!~":
+		if opTok.kind != tokPunct {
+			break
+		}
+		p.advance()
+
+		cmp := jpCompare{op: opTok.text, left: left}
+		if cmp.op == "=~" || cmp.op == "!~" {
+			cmp.re, err = p.regexp()
+		} else {
+			cmp.right, err = p.operand()
+		}
+		if err != nil {
+			return nil, err
+		}
+		return cmp, nil
+	}
+
+	query, ok := left.(jpQuery)
+	if p.filter {
+		return nil, p.errorf("expected comparison operator")
+	}
+	if !ok {
+		return nil, &SyntaxError{
+			Input:  p.input,
+			Offset: leftTok.pos,
+			Msg:    "literal must be compared",
+		}
+	}
+	return jpExists{query: query}, nil
+}
+
+// regexp parses the string literal on the right of =~ as a regular
+// expression.
+func (p *jpParser) regexp() (*regexp.Regexp, error) {
+	tok := p.peek()
+	if tok.kind != tokString {
+		return nil, p.errorf("expected regular expression string")
+	}
+
+	re, err := regexp.Compile(tok.text)
+	if err != nil {
+		return nil, p.errorf("invalid regular expression: %v", err)
+	}
+	p.advance()
+	return re, nil
+}
+
+// operand parses a query from @ or $, or a literal.
+func (p *jpParser) operand() (jpOperand, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokPunct:
+		if p.filter || (tok.text != "@" && tok.text != "$") {
+			break
+		}
+		p.advance()
+
+		path, err := p.segments()
+		if err != nil {
+			return nil, err
+		}
+		return jpQuery{absolute: tok.text == "$", path: path}, nil
+	case tokString:
+		p.advance()
+		return jpLiteral{jpValue{kind: jpString, str: tok.text}}, nil
+	case tokNumber:
+		num, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number")
+		}
+		p.advance()
+		return jpLiteral{jpValue{kind: jpNumber, num: num}}, nil
+	case tokIdent:
+		var v jpValue
+		switch tok.text {
+		case "true", "false":
+			v = jpValue{kind: jpBool, b: tok.text == "true"}
+		case "null":
+			v = jpValue{kind: jpNull}
+		default:
+			if p.filter {
+				return p.property()
+			}
+			return nil, p.errorf("unknown literal %q", tok.text)
+		}
+		p.advance()
+		return jpLiteral{v}, nil
+	}
+	if p.filter {
+		return nil, p.errorf("expected property or literal")
+	}
+	return nil, p.errorf("expected '@', '$' or a literal")
+}
@@ -0,0 +1,240 @@
+package gotree
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const storeJSON = `{
+	"store": {
+		"book": [
+			{"category": "reference", "author": "Nigel Rees",
+				"title": "Sayings of the Century", "price": 8.95},
+			{"category": "fiction", "author": "Evelyn Waugh",
+				"title": "Sword of Honour", "price": 12.99},
+			{"category": "fiction", "author": "Herman Melville",
+				"title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+			{"category": "fiction", "author": "J. R. R. Tolkien",
+				"title": "The Lord of the Rings", "isbn": "0-395-19395-8",
+				"price": 22.99}
+		],
+		"bicycle": {"color": "red", "price": 19.95}
+	},
+	"expensive": 10
+}`
+
+type testBook struct {
+	Title  string
+	Price  float64
+	Author *testAuthor
+}
+
+type testAuthor struct {
+	Name string
+}
+
+func TestQuery(t *testing.T) {
+	var store any
+	if err := json.Unmarshal([]byte(storeJSON), &store); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("TestQueryJSON", func(t *testing.T) {
+		tests := []struct {
+			expr string
+			want []any
+		}{
+			{
+				expr: "$.store.book[*].author",
+				want: []any{"Nigel Rees", "Evelyn Waugh", "Herman Melville",
+					"J. R. R. Tolkien"},
+			},
+			{
+				expr: "$..author",
+				want: []any{"Nigel Rees", "Evelyn Waugh", "Herman Melville",
+					"J. R. R. Tolkien"},
+			},
+			{
+				expr: "$.store..price",
+				want: []any{19.95, 8.95, 12.99, 8.99, 22.99},
+			},
+			{
+				expr: "$..book[2].title",
+				want: []any{"Moby Dick"},
+			},
+			{
+				expr: "$..book[-1].title",
+				want: []any{"The Lord of the Rings"},
+			},
+			{
+				expr: "$..book[0,1].title",
+				want: []any{"Sayings of the Century", "Sword of Honour"},
+			},
+			{
+				expr: "$..book[:2].price",
+				want: []any{8.95, 12.99},
+			},
+			{
+				expr: "$..book[1:3].price",
+				want: []any{12.99, 8.99},
+			},
+			{
+				expr: "$..book[::-2].price",
+				want: []any{22.99, 12.99},
+			},
+			{
+				expr: "$..book[?(@.isbn)].title",
+				want: []any{"Moby Dick", "The Lord of the Rings"},
+			},
+			{
+				expr: "$.store.book[?(@.price < 10)].title",
+				want: []any{"Sayings of the Century", "Moby Dick"},
+			},
+			{
+				expr: "$..book[?(@.price > $.expensive && @.category == 'fiction')].title",
+				want: []any{"Sword of Honour", "The Lord of the Rings"},
+			},
+			{
+				expr: `$..book[?(@.author =~ "^J\\.")].title`,
+				want: []any{"The Lord of the Rings"},
+			},
+			{
+				expr: "$..book[?(!@.isbn || @.price >= 22.99)].price",
+				want: []any{8.95, 12.99, 22.99},
+			},
+			{
+				expr: "$['store']['bicycle']['color']",
+				want: []any{"red"},
+			},
+			{
+				expr: "$.store.bicycle.*",
+				want: []any{"red", 19.95},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.expr, func(t *testing.T) {
+				nodes, err := Query(store, tt.expr, WithKeyOrder(LexicalOrder))
+				if err != nil {
+					t.Fatalf("Query() error = %v", err)
+				}
+
+				got := make([]any, len(nodes))
+				for i, n := range nodes {
+					got[i] = n.Interface
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Query() = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("TestQueryStructs", func(t *testing.T) {
+		books := []testBook{
+			{Title: "Go", Price: 30, Author: &testAuthor{Name: "Alan"}},
+			{Title: "C", Price: 9, Author: &testAuthor{Name: "Brian"}},
+			{Title: "Anon", Price: 5},
+		}
+
+		nodes, err := Query(books, "$[?(@.Price < 10 && @.Author)].Author.Name")
+		if err != nil || len(nodes) != 1 || nodes[0].Interface != "Brian" {
+			t.Fatalf("Query() = (%v, %v), want [Brian]", nodes, err)
+		}
+		if got := nodes[0].Path().String(); got != "[1].Author.Name" {
+			t.Errorf("Path() = %s, want [1].Author.Name", got)
+		}
+		if nodes[0].FullKey != "[1].Author.Name" {
+			t.Errorf("FullKey = %s, want [1].Author.Name", nodes[0].FullKey)
+		}
+	})
+
+	t.Run("TestQueryNestedDescendant", func(t *testing.T) {
+		tree := map[string]any{"a": map[string]any{"b": 1}}
+
+		for _, mode := range []CycleMode{CycleSkip, CycleError} {
+			nodes, err := Query(tree, "$..[?($..b)]", WithCycleMode(mode))
+			if err != nil || len(nodes) != 2 {
+				t.Fatalf("Query(mode %d) = (%v, %v), want a and a.b", mode, nodes, err)
+			}
+			if nodes[0].FullKey != "a" || nodes[1].FullKey != "a.b" {
+				t.Errorf("Query(mode %d) = %s, %s, want a, a.b", mode, nodes[0].FullKey, nodes[1].FullKey)
+			}
+		}
+
+		// A real cycle below a nested query is still reported
+		self := map[string]any{"b": 1}
+		self["self"] = self
+		_, err := Query(map[string]any{"a": self}, "$.a[?(@..c)]", WithCycleMode(CycleError))
+		if !errors.Is(err, ErrCycle) {
+			t.Errorf("Query() error = %v, want %v", err, ErrCycle)
+		}
+	})
+
+	t.Run("TestQueryHugeStep", func(t *testing.T) {
+		arr := []any{1, 2, 3}
+		tests := []struct {
+			expr string
+			want []any
+		}{
+			{expr: "$[1::9223372036854775807]", want: []any{2}},
+			{expr: "$[::9223372036854775807]", want: []any{1}},
+			{expr: "$[1::-9223372036854775808]", want: []any{2}},
+			{expr: "$[::-9223372036854775807]", want: []any{3}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.expr, func(t *testing.T) {
+				nodes, err := Query(arr, tt.expr)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := make([]any, len(nodes))
+				for i, n := range nodes {
+					got[i] = n.Interface
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("Query() = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("TestQueryErrors", func(t *testing.T) {
+		tests := []struct {
+			expr   string
+			offset int
+		}{
+			{expr: "store", offset: 0},
+			{expr: "$.", offset: 2},
+			{expr: "$[1", offset: 3},
+			{expr: "$[?(@.a == )]", offset: 11},
+			{expr: "$[?(@.a =~ 'x(')]", offset: 11},
+			{expr: "$[?(1)]", offset: 4},
+			{expr: "$.a 'b'", offset: 4},
+			{expr: "$['a]", offset: 2},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.expr, func(t *testing.T) {
+				_, err := Query(store, tt.expr)
+				var syntaxErr *SyntaxError
+				if !errors.As(err, &syntaxErr) {
+					t.Fatalf("Query() error = %v, want *SyntaxError", err)
+				}
+				if syntaxErr.Offset != tt.offset {
+					t.Errorf("Query() offset = %d, want %d (%v)", syntaxErr.Offset, tt.offset, err)
+				}
+			})
+		}
+
+		if _, err := Query(store, "$.nothing"); err != ErrNotFound {
+			t.Errorf("Query() error = %v, want %v", err, ErrNotFound)
+		}
+		if _, err := Query(nil, "$"); err != ErrNilTree {
+			t.Errorf("Query(nil) error = %v, want %v", err, ErrNilTree)
+		}
+	})
+}
@@ -0,0 +1,215 @@
+package gotree
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind is the kind of a token read by the lexer.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokPunct
+)
+
+// token is a single token of a query or filter expression.
+type token struct {
+	kind tokenKind
+
+	// text is the source text of the token, or the unquoted value of a
+	// string.
+	text string
+
+	// pos is the byte offset of the token in the input.
+	pos int
+}
+
+// puncts lists the punctuation and operators, longest first so that ".."
+// wins over ".".
+var puncts = []string{
+	"..", "==", "!=", "<=", ">=", "=~", "!~", "&&", "||",
+	".", "$", "@", "*", "[", "]", "(", ")", ",", ":", "?", "<", ">", "!",
+}
+
+// tokenize splits input into tokens, ending with a tokEOF token. It is
+// shared by the JSONPath and filter expression parsers.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+
+	pos := 0
+	for {
+		for pos < len(input) && isSpace(input[pos]) {
+			pos++
+		}
+		if pos >= len(input) {
+			return append(tokens, token{kind: tokEOF, pos: pos}), nil
+		}
+
+		tok, err := lexToken(input, pos)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+		pos = tok.end(input)
+	}
+}
+
+// end returns the offset just after the token.
+func (t token) end(input string) int {
+	switch t.kind {
+	case tokString:
+		return t.pos + stringLen(input[t.pos:])
+	default:
+		return t.pos + len(t.text)
+	}
+}
+
+// lexToken reads the token starting at pos.
+func lexToken(input string, pos int) (token, error) {
+	rest := input[pos:]
+	r, _ := utf8.DecodeRuneInString(rest)
+
+	switch {
+	case r == '"' || r == '\'':
+		s, err := unquote(input, pos)
+		if err != nil {
+			return token{}, err
+		}
+		return token{kind: tokString, text: s, pos: pos}, nil
+	case isDigit(rest[0]) || (rest[0] == '-' && len(rest) > 1 && isDigit(rest[1])):
+		return token{kind: tokNumber, text: numberPrefix(rest), pos: pos}, nil
+	case r == '_' || unicode.IsLetter(r):
+		end := 0
+		for end < len(rest) {
+			r, size := utf8.DecodeRuneInString(rest[end:])
+			if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				break
+			}
+			end += size
+		}
+		return token{kind: tokIdent, text: rest[:end], pos: pos}, nil
+	}
+
+	for _, p := range puncts {
+		if strings.HasPrefix(rest, p) {
+			return token{kind: tokPunct, text: p, pos: pos}, nil
+		}
+	}
+
+	return token{}, &SyntaxError{
+		Input:  input,
+		Offset: pos,
+		Msg:    "unexpected " + strconv.QuoteRune(r),
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// numberPrefix returns the number at the start of s: an optional minus,
+// digits, an optional fraction and an optional exponent.
+func numberPrefix(s string) string {
+	i := 0
+	if s[i] == '-' {
+		i++
+	}
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	if i+1 < len(s) && s[i] == '.' && isDigit(s[i+1]) {
+		i++
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		if j < len(s) && isDigit(s[j]) {
+			for j < len(s) && isDigit(s[j]) {
+				j++
+			}
+			i = j
+		}
+	}
+	return s[:i]
+}
+
+// stringLen returns the length of the quoted string at the start of s,
+// including its quotes.
+func stringLen(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// unquote reads the single or double quoted string starting at pos and
+// returns its value. Both kinds of quote support the escapes of JSON strings
+// plus \' .
+func unquote(input string, pos int) (string, error) {
+	quote := input[pos]
+	fail := func(offset int, msg string) (string, error) {
+		return "", &SyntaxError{Input: input, Offset: offset, Msg: msg}
+	}
+
+	var b strings.Builder
+	for i := pos + 1; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case c == quote:
+			return b.String(), nil
+		case c != '\\':
+			b.WriteByte(c)
+			continue
+		}
+
+		i++
+		if i >= len(input) {
+			break
+		}
+		switch input[i] {
+		case '\\', '/', '\'', '"':
+			b.WriteByte(input[i])
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			if i+4 >= len(input) {
+				return fail(i-1, "invalid unicode escape")
+			}
+			code, err := strconv.ParseUint(input[i+1:i+5], 16, 32)
+			if err != nil {
+				return fail(i-1, "invalid unicode escape")
+			}
+			b.WriteRune(rune(code))
+			i += 4
+		default:
+			return fail(i-1, "invalid escape "+strconv.Quote(input[i-1:i+1]))
+		}
+	}
+
+	return fail(pos, "unterminated string")
+}
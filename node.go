@@ -1,10 +1,14 @@
 package gotree
 
-import "reflect"
+import (
+	"fmt"
+	"reflect"
+)
 
 // Node represents a node in a data structure being traversed. It contains
-// information about the node's location in the structure (FullKey and Key),
-// its value as a reflect.Value, and its value as an interface{}.
+// information about the node's location in the structure (FullKey, Key,
+// Depth, Index, MapKey and Parent), its value as a reflect.Value, and its
+// value as an interface{}.
 type Node struct {
 	// FullKey is the complete path to this node from the root
 	// (e.g., "user.address.street")
@@ -14,21 +18,116 @@ type Node struct {
 	// (e.g., "street" in the example above)
 	Key string
 
-	// Value is the reflect.Value representation of this node's value
+	// Value is the reflect.Value representation of this node's value, with
+	// interfaces and pointers followed to the value they hold. It is only a
+	// nil interface or nil pointer when there is nothing to follow; use IsNil
+	// before calling methods such as Elem on it.
 	Value reflect.Value
 
-	// Interface is the node's value as an interface{}
+	// Interface is the node's value as an interface{}, exactly as it is stored
+	// in the tree, so a pointer field yields the pointer. It is nil when the
+	// value is invalid or a nil interface.
 	Interface any
+
+	// Depth is the number of steps from the root to this node. The root has
+	// depth 0 and its direct children depth 1.
+	Depth int
+
+	// Index is the position of this node in its parent slice or array, or
+	// -1 when the parent is not a slice or array.
+	Index int
+
+	// MapKey is the original key of this node in its parent map, before it
+	// was formatted into Key. It is the zero reflect.Value when the parent
+	// is not a map.
+	MapKey reflect.Value
+
+	// Parent is the node that contains this node, or nil for the root.
+	Parent *Node
+
+	// via is the last pointer that was dereferenced to reach Value
+	via reflect.Value
 }
 
 // newNode creates a new Node with the given full key path, immediate key, and
 // reflect.Value. It automatically extracts the interface{} value from the
-// reflect.Value.
+// reflect.Value, leaving it nil when the value cannot be interfaced, and
+// resolves interfaces and pointers for Node.Value.
 func newNode(fullKey, key string, value reflect.Value) Node {
-	return Node{
-		FullKey:   fullKey,
-		Key:       key,
-		Value:     value,
-		Interface: value.Interface(),
+	node := Node{
+		FullKey: fullKey,
+		Key:     key,
+		Index:   -1,
+	}
+	if value.IsValid() && value.CanInterface() {
+		node.Interface = value.Interface()
+	}
+	node.Value, node.via = resolve(value)
+	return node
+}
+
+// child creates a node below n, one level deeper and with n as its Parent.
+func (n *Node) child(fullKey, key string, value reflect.Value) Node {
+	child := newNode(fullKey, key, value)
+	child.Depth = n.Depth + 1
+	child.Parent = n
+	return child
+}
+
+// mapChild creates the node for the entry of key in the map held by n.
+func (n *Node) mapChild(key, value reflect.Value) Node {
+	strKey := fmt.Sprint(key.Interface())
+	fullKey := strKey
+	if n.FullKey != "" {
+		fullKey = n.FullKey + "." + strKey
+	}
+
+	child := n.child(fullKey, strKey, value)
+	child.MapKey = key
+	return child
+}
+
+// indexChild creates the node for element i of the slice or array held by n.
+func (n *Node) indexChild(i int, value reflect.Value) Node {
+	key := fmt.Sprintf("[%d]", i) // Array key format
+
+	child := n.child(n.FullKey+key, key, value)
+	child.Index = i
+	return child
+}
+
+// fieldChild creates the node for the struct field name of the struct held by
+// n.
+func (n *Node) fieldChild(name string, value reflect.Value) Node {
+	fullKey := name
+	if n.FullKey != "" {
+		fullKey = n.FullKey + "." + name
+	}
+
+	return n.child(fullKey, name, value)
+}
+
+// IsNil reports whether the node holds no value: an invalid reflect.Value, or
+// a nil interface, pointer, map, slice, func or channel.
+func (n Node) IsNil() bool {
+	switch n.Value.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice,
+		reflect.Func, reflect.Chan:
+		return n.Value.IsNil()
+	default:
+		return false
+	}
+}
+
+// Kind returns the kind of the node's value, looking through interfaces. A nil
+// interface, such as a JSON null decoded into any, has kind reflect.Invalid.
+// A nil pointer has kind reflect.Pointer.
+func (n Node) Kind() reflect.Kind {
+	value := n.Value
+	for value.Kind() == reflect.Interface {
+		value = value.Elem()
 	}
+	return value.Kind()
 }
@@ -0,0 +1,136 @@
+package gotree
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestNode(t *testing.T) {
+	t.Run("TestNodeNil", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			value    reflect.Value
+			wantNil  bool
+			wantKind reflect.Kind
+		}{
+			{
+				name:     "Invalid value",
+				value:    reflect.Value{},
+				wantNil:  true,
+				wantKind: reflect.Invalid,
+			},
+			{
+				name:     "Nil interface",
+				value:    reflect.ValueOf(map[string]any{"x": nil}).MapIndex(reflect.ValueOf("x")),
+				wantNil:  true,
+				wantKind: reflect.Invalid,
+			},
+			{
+				name:     "Nil pointer",
+				value:    reflect.ValueOf((*int)(nil)),
+				wantNil:  true,
+				wantKind: reflect.Pointer,
+			},
+			{
+				name:     "Nil map",
+				value:    reflect.ValueOf(map[string]any(nil)),
+				wantNil:  true,
+				wantKind: reflect.Map,
+			},
+			{
+				name:     "String in interface",
+				value:    reflect.ValueOf([]any{"a"}).Index(0),
+				wantNil:  false,
+				wantKind: reflect.String,
+			},
+			{
+				name:     "Zero int",
+				value:    reflect.ValueOf(0),
+				wantNil:  false,
+				wantKind: reflect.Int,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				n := newNode("", "", tt.value)
+				if n.IsNil() != tt.wantNil {
+					t.Errorf("IsNil() = %v, want %v", n.IsNil(), tt.wantNil)
+				}
+				if n.Kind() != tt.wantKind {
+					t.Errorf("Kind() = %v, want %v", n.Kind(), tt.wantKind)
+				}
+			})
+		}
+	})
+
+	t.Run("TestJSONNull", func(t *testing.T) {
+		var tree any
+		doc := `{"a": null, "b": {"c": null, "d": "x"}, "e": [null, 1]}`
+		if err := json.Unmarshal([]byte(doc), &tree); err != nil {
+			t.Fatal(err)
+		}
+
+		nulls, err := Traverse(tree, func(n Node) bool {
+			return n.IsNil()
+		})
+		if err != nil || len(nulls) != 3 {
+			t.Errorf("Traverse() = (%v, %v), want 3 nulls", nulls, err)
+		}
+
+		got, err := FindString(tree, NoneFilter)
+		if got != "x" || err != nil {
+			t.Errorf("FindString() = (%v, %v), want x", got, err)
+		}
+
+		if !Has(tree, func(n Node) bool {
+			return n.FullKey == "b.c" && n.Kind() == reflect.Invalid
+		}) {
+			t.Errorf("Has() = false, want true")
+		}
+	})
+
+	t.Run("TestNodeLocation", func(t *testing.T) {
+		data := map[string]any{
+			"orders": []any{"o1", "o2", "o3"},
+			"tags":   []any{"t1", "t2"},
+			"scores": map[int]string{7: "seven"},
+		}
+
+		got, err := FindString(data, func(n Node) bool {
+			return n.Index == 1 && n.Parent != nil && n.Parent.Key == "orders"
+		})
+		if err != nil || got != "o2" {
+			t.Errorf("FindString() = (%v, %v), want o2", got, err)
+		}
+
+		err = Visit(data, func(n Node) Action {
+			switch n.FullKey {
+			case "":
+				if n.Parent != nil || n.Index != -1 || n.MapKey.IsValid() {
+					t.Errorf("root = %+v, want no parent, index or map key", n)
+				}
+			case "orders":
+				if n.Index != -1 || n.MapKey.String() != "orders" {
+					t.Errorf("orders index = %d, map key = %v", n.Index, n.MapKey)
+				}
+			case "tags[1]":
+				if n.Index != 1 || n.Depth != 2 || n.Parent.Parent.Parent != nil {
+					t.Errorf("tags[1] = %+v, want index 1 at depth 2", n)
+				}
+			case "scores.7":
+				if n.MapKey.Kind() != reflect.Int || n.MapKey.Int() != 7 {
+					t.Errorf("scores.7 map key = %v, want int 7", n.MapKey)
+				}
+				if n.Parent.FullKey != "scores" {
+					t.Errorf("scores.7 parent = %q, want scores", n.Parent.FullKey)
+				}
+			}
+			return Continue
+		})
+		if err != nil {
+			t.Errorf("Visit() error = %v", err)
+		}
+	})
+}
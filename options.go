@@ -0,0 +1,137 @@
+package gotree
+
+// CycleMode controls what the walker does when it reaches a pointer, map or
+// slice that is already being walked further up the tree.
+type CycleMode int
+
+const (
+	// CycleSkip offers the repeated node to the filter but does not descend
+	// into it a second time. This is the default.
+	CycleSkip CycleMode = iota
+
+	// CycleError stops the walk and returns a *PathError wrapping ErrCycle.
+	CycleError
+)
+
+// Order is the order in which the walker visits the nodes of a tree.
+type Order int
+
+const (
+	// PreOrder visits a node before the nodes below it, depth-first. This is
+	// the default.
+	PreOrder Order = iota
+
+	// PostOrder visits a node after the nodes below it, depth-first, e.g. to
+	// compute the sizes of subtrees bottom-up. Returning SkipChildren from a
+	// visit function has no effect, since the children were visited already.
+	// Traverse, its typed variants and Matches still leave out matches
+	// below other matches, testing the nodes above a match with the filter
+	// again to find them.
+	PostOrder
+
+	// BreadthFirst visits the nodes level by level: the root, then every node
+	// at depth 1, then every node at depth 2 and so on. Find then returns
+	// the shallowest match.
+	BreadthFirst
+)
+
+// Options configures how a tree is walked. The zero value walks the whole tree
+// in pre-order, visits map entries in Go's random order and skips cycles.
+//
+// Depths count from the root of the tree, which has depth 0.
+type Options struct {
+	// Order is the order in which nodes are visited.
+	Order Order
+
+	// Cycle decides how self-referencing pointers, maps and slices are handled.
+	Cycle CycleMode
+
+	// KeyOrder sorts map keys before their entries are visited. When nil, map
+	// entries are visited in Go's unspecified iteration order.
+	KeyOrder KeyOrder
+
+	// MaxDepth is the depth of the deepest nodes that are visited. Nothing
+	// below it is walked. Zero or less means no limit.
+	MaxDepth int
+
+	// MinDepth is the depth of the shallowest nodes that are visited. Nodes
+	// above it are still walked through, but never offered to a visit or
+	// filter function.
+	MinDepth int
+
+	// Workers is the number of goroutines ParallelTraverse runs the filter
+	// on. Zero or less means runtime.GOMAXPROCS(0).
+	Workers int
+
+	// SplitDepth is the depth of the subtrees ParallelTraverse hands out to
+	// its workers. Zero or less means 1, i.e. the children of the root.
+	SplitDepth int
+}
+
+// Option sets a field of Options. Options are passed to NewWalker and Walk, and
+// as trailing arguments to Find, Traverse, Has and their typed variants.
+type Option func(*Options)
+
+// WithOrder sets the order in which nodes are visited, e.g. BreadthFirst to
+// make Find return the shallowest match.
+func WithOrder(order Order) Option {
+	return func(o *Options) {
+		o.Order = order
+	}
+}
+
+// WithCycleMode sets how cycles in the tree are handled.
+func WithCycleMode(mode CycleMode) Option {
+	return func(o *Options) {
+		o.Cycle = mode
+	}
+}
+
+// WithKeyOrder sets the order in which map entries are visited, e.g.
+// LexicalOrder, NumericOrder or a custom comparator.
+func WithKeyOrder(order KeyOrder) Option {
+	return func(o *Options) {
+		o.KeyOrder = order
+	}
+}
+
+// WithMaxDepth stops the walk from going deeper than depth. Zero or less
+// removes the limit.
+func WithMaxDepth(depth int) Option {
+	return func(o *Options) {
+		o.MaxDepth = depth
+	}
+}
+
+// WithMinDepth makes the walk ignore nodes shallower than depth, while still
+// descending through them.
+func WithMinDepth(depth int) Option {
+	return func(o *Options) {
+		o.MinDepth = depth
+	}
+}
+
+// WithWorkers sets the number of goroutines used by ParallelTraverse.
+func WithWorkers(n int) Option {
+	return func(o *Options) {
+		o.Workers = n
+	}
+}
+
+// WithSplitDepth sets the depth of the subtrees ParallelTraverse walks
+// concurrently. Deeper splits suit trees whose fan-out is further down, such
+// as a single top-level key holding a large slice of records.
+func WithSplitDepth(depth int) Option {
+	return func(o *Options) {
+		o.SplitDepth = depth
+	}
+}
+
+// newOptions applies opts on top of the zero Options.
+func newOptions(opts []Option) Options {
+	var o Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,133 @@
+package gotree
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// KeyOrder reports whether the map key a should be visited before the map key
+// b. It is used to make walks over maps reproducible.
+type KeyOrder func(a, b reflect.Value) bool
+
+// LexicalOrder orders map keys by comparing their string form byte-wise, so
+// "item10" comes before "item2".
+func LexicalOrder(a, b reflect.Value) bool {
+	return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+}
+
+// NumericOrder orders numeric map keys by value and every other key by its
+// string form, comparing runs of digits as numbers, so "item2" comes before
+// "item10".
+func NumericOrder(a, b reflect.Value) bool {
+	a, b = unwrapKey(a), unwrapKey(b)
+
+	switch {
+	case isInt(a) && isInt(b):
+		return a.Int() < b.Int()
+	case isUint(a) && isUint(b):
+		return a.Uint() < b.Uint()
+	case isFloat(a) && isFloat(b):
+		return a.Float() < b.Float()
+	}
+
+	return naturalLess(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+}
+
+// unwrapKey looks through the interface of keys taken from maps such as
+// map[any]any.
+func unwrapKey(key reflect.Value) reflect.Value {
+	for key.Kind() == reflect.Interface && !key.IsNil() {
+		key = key.Elem()
+	}
+	return key
+}
+
+func isInt(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isUint(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+func isFloat(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// naturalLess compares a and b chunk by chunk, where runs of digits are
+// compared by their numeric value and everything else byte-wise.
+func naturalLess(a, b string) bool {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if !isDigit(a[i]) || !isDigit(b[j]) {
+			if a[i] != b[j] {
+				return a[i] < b[j]
+			}
+			i++
+			j++
+			continue
+		}
+
+		si, sj := i, j
+		for i < len(a) && isDigit(a[i]) {
+			i++
+		}
+		for j < len(b) && isDigit(b[j]) {
+			j++
+		}
+
+		// Leading zeros do not change the value of a number
+		na, nb := trimZeros(a[si:i]), trimZeros(b[sj:j])
+		if len(na) != len(nb) {
+			return len(na) < len(nb)
+		}
+		if na != nb {
+			return na < nb
+		}
+	}
+
+	if len(a)-i != len(b)-j {
+		return len(a)-i < len(b)-j
+	}
+	return a < b
+}
+
+func trimZeros(s string) string {
+	for len(s) > 1 && s[0] == '0' {
+		s = s[1:]
+	}
+	return s
+}
+
+// mapKeys returns the keys of the map held by value, sorted by the KeyOrder
+// of s when one is set.
+func (s *walkState) mapKeys(value reflect.Value) []reflect.Value {
+	keys := value.MapKeys()
+	if order := s.opts.KeyOrder; order != nil {
+		sort.SliceStable(keys, func(i, j int) bool {
+			return order(keys[i], keys[j])
+		})
+	}
+	return keys
+}
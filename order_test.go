@@ -0,0 +1,94 @@
+package gotree
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeyOrder(t *testing.T) {
+	items := map[string]string{
+		"item10": "item10",
+		"item2":  "item2",
+		"item1":  "item1",
+		"Item3":  "Item3",
+	}
+	numbers := map[int]string{10: "10", -1: "-1", 2: "2"}
+
+	tests := []struct {
+		name  string
+		tree  any
+		order KeyOrder
+		want  []string
+	}{
+		{
+			name:  "Lexical order",
+			tree:  items,
+			order: LexicalOrder,
+			want:  []string{"Item3", "item1", "item10", "item2"},
+		},
+		{
+			name:  "Numeric order",
+			tree:  items,
+			order: NumericOrder,
+			want:  []string{"Item3", "item1", "item2", "item10"},
+		},
+		{
+			name:  "Numeric order of int keys",
+			tree:  numbers,
+			order: NumericOrder,
+			want:  []string{"-1", "2", "10"},
+		},
+		{
+			name: "Custom order",
+			tree: items,
+			order: func(a, b reflect.Value) bool {
+				return a.String() > b.String()
+			},
+			want: []string{"item2", "item10", "item1", "Item3"},
+		},
+		{
+			name:  "Nested maps",
+			tree:  map[string]any{"b": items, "a": "a"},
+			order: NumericOrder,
+			want:  []string{"a", "Item3", "item1", "item2", "item10"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Repeat to catch Go's randomised map iteration
+			for i := 0; i < 10; i++ {
+				got, err := TraverseString(tt.tree, NoneFilter,
+					WithKeyOrder(tt.order))
+				if err != nil || !reflect.DeepEqual(got, tt.want) {
+					t.Fatalf("TraverseString() = (%v, %v), want %v", got, err, tt.want)
+				}
+			}
+
+			first, err := FindString(tt.tree, NoneFilter, WithKeyOrder(tt.order))
+			if err != nil || first != tt.want[0] {
+				t.Errorf("FindString() = (%v, %v), want %v", first, err, tt.want[0])
+			}
+		})
+	}
+
+	t.Run("TestNaturalLess", func(t *testing.T) {
+		tests := []struct {
+			a, b string
+			want bool
+		}{
+			{"a2", "a10", true},
+			{"a10", "a2", false},
+			{"a02", "a2", true},
+			{"a", "a1", true},
+			{"2b", "10a", true},
+			{"x", "x", false},
+		}
+
+		for _, tt := range tests {
+			if got := naturalLess(tt.a, tt.b); got != tt.want {
+				t.Errorf("naturalLess(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		}
+	})
+}
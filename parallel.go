@@ -0,0 +1,153 @@
+package gotree
+
+import (
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// ParallelTraverse works like Traverse, but walks the subtrees at SplitDepth
+// concurrently on a pool of Workers goroutines, see WithSplitDepth and
+// WithWorkers. It pays off for large fan-out structures, such as slices of
+// many records, with filters that do real work.
+//
+// The filter is called from several goroutines at once and must be safe for
+// concurrent use. The results are returned in the same order as Traverse
+// returns them in PreOrder, whatever the Order option says. Since that order
+// has to be reproducible, map entries are visited in LexicalOrder unless
+// another KeyOrder is set.
+func ParallelTraverse(tree any, filter FilterFunc, opts ...Option) ([]any, error) {
+	nodes, err := NewWalker(opts...).parallelTraverseNodes(tree, filter)
+	if err != nil {
+		return []any{}, err
+	}
+
+	values := make([]any, len(nodes))
+	for i, v := range nodes {
+		values[i] = v.Interface
+	}
+	return values, nil
+}
+
+// subtree is a part of the result of ParallelTraverse: a node matched while
+// splitting the tree, or a subtree walked by a worker.
+type subtree struct {
+	root Node
+
+	// ancestors holds the pointers, maps and slices above root, shared with
+	// the siblings of root.
+	ancestors map[visitKey]struct{}
+
+	// pending is set until a worker has walked the subtree.
+	pending bool
+
+	nodes []Node
+	err   error
+}
+
+// parallelTraverseNodes walks the tree down to SplitDepth, collecting the
+// matches found on the way, and then walks the subtrees below on a pool of
+// workers. Matches are not descended into, as with Traverse.
+func (w *Walker) parallelTraverseNodes(tree any, filter FilterFunc) ([]Node, error) {
+	if tree == nil {
+		return nil, ErrNilTree
+	}
+
+	opts := w.opts
+	if opts.KeyOrder == nil {
+		opts.KeyOrder = LexicalOrder
+	}
+	splitDepth := opts.SplitDepth
+	if splitDepth < 1 {
+		splitDepth = 1
+	}
+	workers := opts.Workers
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	// MinDepth is applied here rather than by the walk, which would not
+	// offer the nodes above it for splitting
+	splitOpts := opts
+	splitOpts.MinDepth = 0
+	s := newWalkState(splitOpts)
+
+	var (
+		parts     []*subtree
+		parent    *Node
+		ancestors map[visitKey]struct{}
+	)
+	s.walk(newNode("", "", reflect.ValueOf(tree)), func(n Node) Action {
+		if n.Depth == splitDepth {
+			// Siblings share the nodes above them
+			if n.Parent != parent {
+				parent, ancestors = n.Parent, copyVisiting(s.visiting)
+			}
+			parts = append(parts, &subtree{root: n, ancestors: ancestors, pending: true})
+			return SkipChildren
+		}
+
+		if n.Depth >= opts.MinDepth && test(n, filter) {
+			parts = append(parts, &subtree{root: n, nodes: []Node{n}})
+			return SkipChildren
+		}
+		return Continue
+	})
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	tasks := make(chan *subtree)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for part := range tasks {
+				part.walk(opts, filter)
+			}
+		}()
+	}
+	for _, part := range parts {
+		if part.pending {
+			tasks <- part
+		}
+	}
+	close(tasks)
+	wg.Wait()
+
+	var nodes []Node
+	for _, part := range parts {
+		if part.err != nil {
+			return nil, part.err
+		}
+		nodes = append(nodes, part.nodes...)
+	}
+	if len(nodes) == 0 {
+		return nil, ErrNotFound
+	}
+	return nodes, nil
+}
+
+// walk collects the matches of filter in the subtree.
+func (part *subtree) walk(opts Options, filter FilterFunc) {
+	s := newWalkState(opts)
+	s.ancestors = part.ancestors
+	s.walk(part.root, func(n Node) Action {
+		if !test(n, filter) {
+			return Continue
+		}
+		part.nodes = append(part.nodes, n)
+		return SkipChildren
+	})
+	part.err = s.err
+}
+
+// copyVisiting returns a copy of the set of nodes being walked.
+func copyVisiting(visiting map[visitKey]struct{}) map[visitKey]struct{} {
+	c := make(map[visitKey]struct{}, len(visiting))
+	for key := range visiting {
+		c[key] = struct{}{}
+	}
+	return c
+}
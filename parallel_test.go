@@ -0,0 +1,90 @@
+package gotree
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParallelTraverse(t *testing.T) {
+	records := make([]any, 500)
+	for i := range records {
+		records[i] = map[string]any{
+			"id":   i,
+			"name": fmt.Sprintf("user-%d", i),
+			"tags": []any{"a", fmt.Sprint(i % 7)},
+		}
+	}
+	data := map[string]any{"records": records, "total": len(records), "name": "all"}
+
+	isName := KeyFilter("name")
+
+	t.Run("TestParallelTraverseMatchesTraverse", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			filter FilterFunc
+			opts   []Option
+		}{
+			{name: "Default", filter: isName},
+			{name: "One worker", filter: isName, opts: []Option{WithWorkers(1)}},
+			{name: "Split depth 2", filter: isName, opts: []Option{WithSplitDepth(2), WithWorkers(4)}},
+			{name: "Split below matches", filter: KeyFilter("tags"), opts: []Option{WithSplitDepth(4)}},
+			{name: "Strings", filter: FilterString(NoneFilter), opts: []Option{WithSplitDepth(3)}},
+			{name: "Min depth", filter: FilterString(NoneFilter), opts: []Option{WithMinDepth(4)}},
+			{name: "Max depth", filter: FilterString(NoneFilter), opts: []Option{WithMaxDepth(1)}},
+			{
+				name:   "Numeric order",
+				filter: FilterInt(NoneFilter),
+				opts:   []Option{WithKeyOrder(NumericOrder), WithSplitDepth(2)},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				opts := append([]Option{WithKeyOrder(LexicalOrder)}, tt.opts...)
+				want, err := Traverse(data, tt.filter, opts...)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got, err := ParallelTraverse(data, tt.filter, tt.opts...)
+				if err != nil || !reflect.DeepEqual(got, want) {
+					t.Errorf("ParallelTraverse() = (%d values, %v), want %d values in Traverse order",
+						len(got), err, len(want))
+				}
+			})
+		}
+	})
+
+	t.Run("TestParallelTraverseErrors", func(t *testing.T) {
+		if _, err := ParallelTraverse(nil, isName); err != ErrNilTree {
+			t.Errorf("ParallelTraverse(nil) error = %v, want %v", err, ErrNilTree)
+		}
+		if _, err := ParallelTraverse(data, KeyFilter("missing")); err != ErrNotFound {
+			t.Errorf("ParallelTraverse() error = %v, want %v", err, ErrNotFound)
+		}
+
+		got, err := ParallelTraverse("leaf", NoneFilter)
+		if err != nil || !reflect.DeepEqual(got, []any{"leaf"}) {
+			t.Errorf("ParallelTraverse(leaf) = (%v, %v), want [leaf]", got, err)
+		}
+	})
+
+	t.Run("TestParallelTraverseCycles", func(t *testing.T) {
+		ring := &testLink{Name: "a", Next: &testLink{Name: "b", Next: &testLink{Name: "c"}}}
+		ring.Next.Next.Next = ring
+
+		got, err := ParallelTraverse(ring, KeyFilter("Name"), WithSplitDepth(2))
+		want := []any{"a", "b", "c"}
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("ParallelTraverse() = (%v, %v), want %v", got, err, want)
+		}
+
+		_, err = ParallelTraverse(ring, KeyFilter("Name"),
+			WithSplitDepth(2), WithCycleMode(CycleError))
+		if !errors.Is(err, ErrCycle) {
+			t.Errorf("ParallelTraverse() error = %v, want %v", err, ErrCycle)
+		}
+	})
+}
@@ -0,0 +1,345 @@
+package gotree
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SegmentKind tells what a Segment of a Path steps into.
+type SegmentKind int
+
+const (
+	// KeySegment selects an entry of a map by its key.
+	KeySegment SegmentKind = iota
+
+	// FieldSegment selects an exported field of a struct by its name.
+	FieldSegment
+
+	// IndexSegment selects an element of a slice or array by its index.
+	IndexSegment
+)
+
+// Segment is a single step of a Path.
+type Segment struct {
+	Kind SegmentKind
+
+	// Key is the map key or field name for KeySegment and FieldSegment.
+	Key string
+
+	// Index is the element index for IndexSegment.
+	Index int
+}
+
+// String returns the segment as it appears in the middle of a Path.
+func (s Segment) String() string {
+	var b strings.Builder
+	s.write(&b, false)
+	return b.String()
+}
+
+// write appends the canonical form of s to b. The leading dot of a plain key
+// is left out for the first segment of a path.
+func (s Segment) write(b *strings.Builder, first bool) {
+	switch {
+	case s.Kind == IndexSegment:
+		b.WriteByte('[')
+		b.WriteString(strconv.Itoa(s.Index))
+		b.WriteByte(']')
+	case isPlainKey(s.Key):
+		if !first {
+			b.WriteByte('.')
+		}
+		b.WriteString(s.Key)
+	default:
+		b.WriteByte('[')
+		b.WriteString(strconv.Quote(s.Key))
+		b.WriteByte(']')
+	}
+}
+
+// isPlainKey reports whether key can be written without quoting, i.e. it is
+// made only of letters, digits, '_' and '-'.
+func isPlainKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if !isPlainRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isPlainRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-'
+}
+
+// Path is the location of a node as the list of segments leading to it from
+// the root. Unlike Node.FullKey, its String form is unambiguous: keys that are
+// not plain words are quoted, so "a.b" as a single key is written ["a.b"],
+// while the nested keys a and b are written a.b.
+type Path []Segment
+
+// String returns the canonical form of p, e.g. users[0].name or
+// config["log.level"]. ParsePath turns it back into an equal Path.
+func (p Path) String() string {
+	var b strings.Builder
+	for i, s := range p {
+		s.write(&b, i == 0)
+	}
+	return b.String()
+}
+
+// Equal reports whether p and q address the same location. Key and field
+// segments are compared by name only, since the textual form does not tell
+// them apart.
+func (p Path) Equal(q Path) bool {
+	if len(p) != len(q) {
+		return false
+	}
+	for i := range p {
+		if !p[i].matches(q[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether s and o address the same child.
+func (s Segment) matches(o Segment) bool {
+	if (s.Kind == IndexSegment) != (o.Kind == IndexSegment) {
+		return false
+	}
+	if s.Kind == IndexSegment {
+		return s.Index == o.Index
+	}
+	return s.Key == o.Key
+}
+
+// Path returns the location of n from the root of the tree it was found in.
+// It is built from the Parent chain, so the root has an empty Path.
+func (n Node) Path() Path {
+	depth := 0
+	for p := &n; p.Parent != nil; p = p.Parent {
+		depth++
+	}
+
+	path := make(Path, depth)
+	for p := &n; p.Parent != nil; p = p.Parent {
+		depth--
+		path[depth] = p.segment()
+	}
+	return path
+}
+
+// segment returns the last segment of the path of n.
+func (n *Node) segment() Segment {
+	switch {
+	case n.Index >= 0:
+		return Segment{Kind: IndexSegment, Index: n.Index}
+	case n.MapKey.IsValid():
+		return Segment{Kind: KeySegment, Key: n.Key}
+	default:
+		return Segment{Kind: FieldSegment, Key: n.Key}
+	}
+}
+
+// SyntaxError reports a malformed path or expression, and where in the input
+// the problem was found.
+type SyntaxError struct {
+	// Input is the text that failed to parse.
+	Input string
+
+	// Offset is the byte offset of the problem in Input.
+	Offset int
+
+	// Msg describes the problem.
+	Msg string
+}
+
+// Error reports the position of the problem as a column counted in runes,
+// starting at 1.
+func (e *SyntaxError) Error() string {
+	offset := e.Offset
+	if offset > len(e.Input) {
+		offset = len(e.Input)
+	}
+	column := utf8.RuneCountInString(e.Input[:offset]) + 1
+	return fmt.Sprintf("%s at column %d in %q", e.Msg, column, e.Input)
+}
+
+// ParsePath parses the canonical form written by Path.String. Plain keys are
+// separated by dots, indexes are written as [0] and any other key as a quoted
+// Go string in brackets, e.g. users[0]["e-mail.home"].
+//
+// Since the textual form does not tell map keys and struct fields apart, named
+// segments are returned as KeySegment. Path.Equal and the lookup functions
+// treat both kinds alike.
+func ParsePath(s string) (Path, error) {
+	p := pathParser{input: s}
+	segs, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	path := make(Path, len(segs))
+	for i, seg := range segs {
+		path[i] = seg.Segment
+	}
+	return path, nil
+}
+
+// pathParser reads the segments of a path from input. With wildcards set it
+// also accepts the wildcards of path patterns.
+type pathParser struct {
+	input     string
+	pos       int
+	wildcards bool
+}
+
+func (p *pathParser) errorf(format string, args ...any) error {
+	return &SyntaxError{
+		Input:  p.input,
+		Offset: p.pos,
+		Msg:    fmt.Sprintf(format, args...),
+	}
+}
+
+func (p *pathParser) parse() ([]patternSegment, error) {
+	segs := make([]patternSegment, 0)
+
+	for p.pos < len(p.input) {
+		switch c := p.input[p.pos]; {
+		case c == '[':
+			seg, err := p.bracket()
+			if err != nil {
+				return nil, err
+			}
+			segs = append(segs, seg)
+		case c == '.' && len(segs) > 0:
+			p.pos++
+			seg, ok := p.name()
+			if !ok {
+				return nil, p.errorf("expected key after '.'")
+			}
+			segs = append(segs, seg)
+		case len(segs) == 0:
+			seg, ok := p.name()
+			if !ok {
+				return nil, p.errorf("unexpected %q", c)
+			}
+			segs = append(segs, seg)
+		default:
+			return nil, p.errorf("unexpected %q", c)
+		}
+	}
+
+	return segs, nil
+}
+
+// name reads a plain key or, when wildcards are allowed, * or **.
+func (p *pathParser) name() (patternSegment, bool) {
+	if p.wildcards {
+		rest := p.input[p.pos:]
+		switch {
+		case strings.HasPrefix(rest, "**"):
+			p.pos += 2
+			return patternSegment{wildcard: anySegments}, true
+		case strings.HasPrefix(rest, "*"):
+			p.pos++
+			return patternSegment{wildcard: anyKey}, true
+		}
+	}
+
+	key := p.plainKey()
+	if key == "" {
+		return patternSegment{}, false
+	}
+	seg := Segment{Kind: KeySegment, Key: key}
+	return patternSegment{Segment: seg}, true
+}
+
+// plainKey reads the longest plain key at the current position.
+func (p *pathParser) plainKey() string {
+	start := p.pos
+	for p.pos < len(p.input) {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		if !isPlainRune(r) {
+			break
+		}
+		p.pos += size
+	}
+	return p.input[start:p.pos]
+}
+
+// bracket reads an [index] or ["quoted key"] segment, or [*] when wildcards
+// are allowed.
+func (p *pathParser) bracket() (patternSegment, error) {
+	p.pos++ // '['
+	if p.pos >= len(p.input) {
+		return patternSegment{}, p.errorf("unterminated '['")
+	}
+
+	var seg patternSegment
+	switch {
+	case p.input[p.pos] == '"':
+		quoted, err := p.quoted()
+		if err != nil {
+			return patternSegment{}, err
+		}
+		seg.Segment = Segment{Kind: KeySegment, Key: quoted}
+	case p.wildcards && p.input[p.pos] == '*':
+		p.pos++
+		seg.wildcard = anyIndex
+	default:
+		start := p.pos
+		for p.pos < len(p.input) && isDigit(p.input[p.pos]) {
+			p.pos++
+		}
+		if start == p.pos {
+			return patternSegment{}, p.errorf("expected index or quoted key")
+		}
+
+		index, err := strconv.Atoi(p.input[start:p.pos])
+		if err != nil {
+			p.pos = start
+			return patternSegment{}, p.errorf("invalid index")
+		}
+		seg.Segment = Segment{Kind: IndexSegment, Index: index}
+	}
+
+	if p.pos >= len(p.input) || p.input[p.pos] != ']' {
+		return patternSegment{}, p.errorf("expected ']'")
+	}
+	p.pos++
+	return seg, nil
+}
+
+// quoted reads a double quoted Go string literal.
+func (p *pathParser) quoted() (string, error) {
+	start := p.pos
+	p.pos++ // opening quote
+	for p.pos < len(p.input) {
+		switch p.input[p.pos] {
+		case '\\':
+			p.pos += 2
+			continue
+		case '"':
+			p.pos++
+			s, err := strconv.Unquote(p.input[start:p.pos])
+			if err != nil {
+				p.pos = start
+				return "", p.errorf("invalid quoted key")
+			}
+			return s, nil
+		}
+		p.pos++
+	}
+
+	p.pos = start
+	return "", p.errorf("unterminated quoted key")
+}
@@ -0,0 +1,139 @@
+package gotree
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPath(t *testing.T) {
+	t.Run("TestPathString", func(t *testing.T) {
+		tests := []struct {
+			name string
+			path Path
+			want string
+		}{
+			{
+				name: "Root",
+				path: Path{},
+				want: "",
+			},
+			{
+				name: "Plain keys and index",
+				path: Path{
+					{Kind: KeySegment, Key: "users"},
+					{Kind: IndexSegment, Index: 0},
+					{Kind: FieldSegment, Key: "Name"},
+				},
+				want: "users[0].Name",
+			},
+			{
+				name: "Key with a dot",
+				path: Path{
+					{Kind: KeySegment, Key: "config"},
+					{Kind: KeySegment, Key: "log.level"},
+				},
+				want: `config["log.level"]`,
+			},
+			{
+				name: "Leading quoted key",
+				path: Path{
+					{Kind: KeySegment, Key: "a[0]"},
+					{Kind: KeySegment, Key: "b"},
+				},
+				want: `["a[0]"].b`,
+			},
+			{
+				name: "Empty key and quotes",
+				path: Path{
+					{Kind: KeySegment, Key: ""},
+					{Kind: KeySegment, Key: `say "hi"`},
+				},
+				want: `[""]["say \"hi\""]`,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got := tt.path.String()
+				if got != tt.want {
+					t.Errorf("String() = %s, want %s", got, tt.want)
+				}
+
+				parsed, err := ParsePath(got)
+				if err != nil || !parsed.Equal(tt.path) {
+					t.Errorf("ParsePath(%s) = (%v, %v), want %v", got, parsed, err, tt.path)
+				}
+			})
+		}
+	})
+
+	t.Run("TestParsePathErrors", func(t *testing.T) {
+		tests := []struct {
+			input  string
+			offset int
+		}{
+			{input: ".a", offset: 0},
+			{input: "a..b", offset: 2},
+			{input: "a[", offset: 2},
+			{input: "a[x]", offset: 2},
+			{input: "a[0", offset: 3},
+			{input: `a["b]`, offset: 2},
+			{input: "a b", offset: 1},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.input, func(t *testing.T) {
+				_, err := ParsePath(tt.input)
+				var syntaxErr *SyntaxError
+				if !errors.As(err, &syntaxErr) {
+					t.Fatalf("ParsePath(%q) error = %v, want *SyntaxError", tt.input, err)
+				}
+				if syntaxErr.Offset != tt.offset {
+					t.Errorf("ParsePath(%q) offset = %d, want %d", tt.input, syntaxErr.Offset, tt.offset)
+				}
+			})
+		}
+	})
+
+	t.Run("TestNodePath", func(t *testing.T) {
+		data := map[string]any{
+			"a.b":   map[string]any{"c": 1},
+			"a":     map[string]any{"b": map[string]any{"c": 2}},
+			"users": []testUser{{Name: "Alice"}},
+		}
+
+		want := map[string]string{
+			`["a.b"].c`:     "a.b.c",
+			"a.b.c":         "a.b.c",
+			"users[0].Name": "users[0].Name",
+		}
+
+		found := 0
+		err := Visit(data, func(n Node) Action {
+			fullKey, ok := want[n.Path().String()]
+			if !ok {
+				return Continue
+			}
+			found++
+			if n.FullKey != fullKey {
+				t.Errorf("Path() = %s for FullKey %s, want %s", n.Path(), n.FullKey, fullKey)
+			}
+			return Continue
+		})
+		if err != nil || found != len(want) {
+			t.Errorf("Visit() = (%d paths, %v), want %d paths", found, err, len(want))
+		}
+
+		_ = Visit(data, func(n Node) Action {
+			if n.Key != "Name" {
+				return Continue
+			}
+			path := n.Path()
+			if path[0].Kind != KeySegment || path[1].Kind != IndexSegment ||
+				path[2].Kind != FieldSegment {
+				t.Errorf("Path() kinds = %+v", path)
+			}
+			return Stop
+		})
+	})
+}
@@ -0,0 +1,102 @@
+package gotree
+
+// wildcard tells which wildcard, if any, a pattern segment is.
+type wildcard int
+
+const (
+	// noWildcard matches the segment literally.
+	noWildcard wildcard = iota
+
+	// anyKey is written * and matches any single map key or struct field.
+	anyKey
+
+	// anyIndex is written [*] and matches any single slice or array index.
+	anyIndex
+
+	// anySegments is written ** and matches any number of segments,
+	// including none.
+	anySegments
+)
+
+// patternSegment is a Segment of a path pattern, or one of its wildcards.
+type patternSegment struct {
+	Segment
+	wildcard wildcard
+}
+
+// matches reports whether the single path segment seg is matched by s.
+func (s patternSegment) matches(seg Segment) bool {
+	switch s.wildcard {
+	case anyKey:
+		return seg.Kind != IndexSegment
+	case anyIndex:
+		return seg.Kind == IndexSegment
+	default:
+		return s.Segment.matches(seg)
+	}
+}
+
+// PathPattern is a compiled path pattern. Patterns use the syntax of
+// ParsePath with three wildcards:
+//
+//   - * matches any single map key or struct field, e.g. users.*.name
+//   - [*] matches any single slice or array index, e.g. users[*].name
+//   - ** matches any number of segments, including none, e.g. **.id
+//
+// Quoting a key, as in ["*"], matches it literally. A PathPattern is
+// immutable and safe for concurrent use.
+type PathPattern struct {
+	source string
+	segs   []patternSegment
+}
+
+// CompilePathPattern parses a path pattern. It returns a *SyntaxError if the
+// pattern is malformed.
+func CompilePathPattern(pattern string) (*PathPattern, error) {
+	p := pathParser{input: pattern, wildcards: true}
+	segs, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &PathPattern{source: pattern, segs: segs}, nil
+}
+
+// MustCompilePathPattern is like CompilePathPattern but panics if the pattern
+// is malformed.
+func MustCompilePathPattern(pattern string) *PathPattern {
+	p, err := CompilePathPattern(pattern)
+	if err != nil {
+		panic("gotree: " + err.Error())
+	}
+	return p
+}
+
+// String returns the source text of the pattern.
+func (p *PathPattern) String() string {
+	return p.source
+}
+
+// Match reports whether path is matched by the pattern as a whole.
+func (p *PathPattern) Match(path Path) bool {
+	return matchSegments(p.segs, path)
+}
+
+// matchSegments matches path against segs, backtracking over ** wildcards.
+func matchSegments(segs []patternSegment, path Path) bool {
+	for len(segs) > 0 {
+		if segs[0].wildcard == anySegments {
+			for i := 0; i <= len(path); i++ {
+				if matchSegments(segs[1:], path[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(path) == 0 || !segs[0].matches(path[0]) {
+			return false
+		}
+		segs, path = segs[1:], path[1:]
+	}
+	return len(path) == 0
+}
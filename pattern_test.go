@@ -0,0 +1,77 @@
+package gotree
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPathPattern(t *testing.T) {
+	t.Run("TestPathPatternMatch", func(t *testing.T) {
+		tests := []struct {
+			pattern string
+			path    string
+			want    bool
+		}{
+			{pattern: "users[*].address.*", path: "users[3].address.city", want: true},
+			{pattern: "users[*].address.*", path: "users[3].address", want: false},
+			{pattern: "users[*].address.*", path: "users.x.address.city", want: false},
+			{pattern: "users.*", path: "users[0]", want: false},
+			{pattern: "**.id", path: "id", want: true},
+			{pattern: "**.id", path: "a[1].b.id", want: true},
+			{pattern: "**.id", path: "a.id.b", want: false},
+			{pattern: "a.**", path: "a", want: true},
+			{pattern: "a.**.z", path: "a.b[2].c.z", want: true},
+			{pattern: "**", path: "", want: true},
+			{pattern: `["*"]`, path: `["*"]`, want: true},
+			{pattern: `["*"]`, path: "x", want: false},
+			{pattern: `config["log.level"]`, path: `config["log.level"]`, want: true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+				path, err := ParsePath(tt.path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				p := MustCompilePathPattern(tt.pattern)
+				if got := p.Match(path); got != tt.want {
+					t.Errorf("Match(%s) = %v, want %v", tt.path, got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("TestPathPatternErrors", func(t *testing.T) {
+		for _, pattern := range []string{"a*", "a.[*]", "a[*", "***x"} {
+			_, err := CompilePathPattern(pattern)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Errorf("CompilePathPattern(%q) error = %v, want *SyntaxError", pattern, err)
+			}
+		}
+
+		if _, err := ParsePath("users[*]"); err == nil {
+			t.Errorf("ParsePath() accepted a wildcard")
+		}
+	})
+
+	t.Run("TestPathPatternFilter", func(t *testing.T) {
+		data := map[string]any{
+			"users": []any{
+				map[string]any{"address": map[string]any{"city": "Oslo", "zip": "0150"}},
+				map[string]any{"address": map[string]any{"city": "Rome"}},
+			},
+		}
+
+		got, err := TraverseString(data, PathPatternFilter("users[*].address.city"))
+		want := []string{"Oslo", "Rome"}
+		if err != nil || !EqualSlices(t, want, got) {
+			t.Errorf("TraverseString() = (%v, %v), want %v", got, err, want)
+		}
+
+		got, err = TraverseString(data, PathPatternFilter("**.zip"))
+		if err != nil || len(got) != 1 || got[0] != "0150" {
+			t.Errorf("TraverseString() = (%v, %v), want [0150]", got, err)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package gotree
+
+import (
+	"strconv"
+	"strings"
+)
+
+// pointerEscaper escapes a JSON Pointer reference token as RFC 6901 requires.
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// JSONPointer returns p as an RFC 6901 JSON Pointer, e.g. /users/0/name. The
+// root is the empty string.
+func (p Path) JSONPointer() string {
+	var b strings.Builder
+	for _, seg := range p {
+		b.WriteByte('/')
+		if seg.Kind == IndexSegment {
+			b.WriteString(strconv.Itoa(seg.Index))
+		} else {
+			pointerEscaper.WriteString(&b, seg.Key)
+		}
+	}
+	return b.String()
+}
+
+// JSONPointer returns the location of n as an RFC 6901 JSON Pointer.
+func (n Node) JSONPointer() string {
+	return n.Path().JSONPointer()
+}
+
+// ParseJSONPointer parses an RFC 6901 JSON Pointer into a Path. The empty
+// pointer addresses the root. Since a pointer does not tell keys and indexes
+// apart, every reference token becomes a KeySegment; the lookup functions use
+// tokens made of digits as indexes into slices and arrays.
+func ParseJSONPointer(ptr string) (Path, error) {
+	if ptr == "" {
+		return Path{}, nil
+	}
+	if ptr[0] != '/' {
+		return nil, &SyntaxError{
+			Input: ptr,
+			Msg:   "JSON Pointer must start with '/'",
+		}
+	}
+
+	path := make(Path, 0, strings.Count(ptr, "/"))
+	start := 1
+	for start <= len(ptr) {
+		end := strings.IndexByte(ptr[start:], '/')
+		if end < 0 {
+			end = len(ptr)
+		} else {
+			end += start
+		}
+
+		token := ptr[start:end]
+		for i := 0; i < len(token); i++ {
+			if token[i] != '~' {
+				continue
+			}
+			if i+1 >= len(token) || (token[i+1] != '0' && token[i+1] != '1') {
+				return nil, &SyntaxError{
+					Input:  ptr,
+					Offset: start + i,
+					Msg:    "invalid escape in JSON Pointer",
+				}
+			}
+		}
+
+		token = strings.ReplaceAll(token, "~1", "/")
+		token = strings.ReplaceAll(token, "~0", "~")
+		path = append(path, Segment{Kind: KeySegment, Key: token})
+		start = end + 1
+	}
+
+	return path, nil
+}
+
+// GetPointer returns the value addressed by the RFC 6901 JSON Pointer ptr in
+// tree, e.g. /users/0/name. It returns ErrNilTree if tree is nil, a
+// *SyntaxError if ptr is malformed and a *PathError wrapping ErrNotFound for
+// the first reference token that does not exist.
+func GetPointer(tree any, ptr string) (any, error) {
+	path, err := ParseJSONPointer(ptr)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := lookup(tree, path)
+	if err != nil {
+		return nil, err
+	}
+	return node.Interface, nil
+}
+
+// arrayIndex parses a reference token used as an array index. Like RFC 6901,
+// it only accepts decimal digits without leading zeros.
+func arrayIndex(token string) (int, bool) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+	for i := 0; i < len(token); i++ {
+		if !isDigit(token[i]) {
+			return 0, false
+		}
+	}
+
+	index, err := strconv.Atoi(token)
+	return index, err == nil
+}
@@ -0,0 +1,100 @@
+package gotree
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestJSONPointer(t *testing.T) {
+	// The example document of RFC 6901, section 5
+	var doc any
+	err := json.Unmarshal([]byte(`{
+		"foo": ["bar", "baz"],
+		"": 0,
+		"a/b": 1,
+		"c%d": 2,
+		"e^f": 3,
+		"g|h": 4,
+		"i\\j": 5,
+		"k\"l": 6,
+		" ": 7,
+		"m~n": 8
+	}`), &doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("TestGetPointer", func(t *testing.T) {
+		tests := []struct {
+			ptr  string
+			want any
+		}{
+			{ptr: "", want: doc},
+			{ptr: "/foo", want: []any{"bar", "baz"}},
+			{ptr: "/foo/0", want: "bar"},
+			{ptr: "/", want: 0.0},
+			{ptr: "/a~1b", want: 1.0},
+			{ptr: "/c%d", want: 2.0},
+			{ptr: "/e^f", want: 3.0},
+			{ptr: "/g|h", want: 4.0},
+			{ptr: "/i\\j", want: 5.0},
+			{ptr: "/k\"l", want: 6.0},
+			{ptr: "/ ", want: 7.0},
+			{ptr: "/m~0n", want: 8.0},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.ptr, func(t *testing.T) {
+				got, err := GetPointer(doc, tt.ptr)
+				if err != nil || !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("GetPointer() = (%v, %v), want %v", got, err, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("TestGetPointerErrors", func(t *testing.T) {
+		var syntaxErr *SyntaxError
+		for _, ptr := range []string{"foo", "/m~2n", "/a~"} {
+			if _, err := GetPointer(doc, ptr); !errors.As(err, &syntaxErr) {
+				t.Errorf("GetPointer(%q) error = %v, want *SyntaxError", ptr, err)
+			}
+		}
+
+		for _, ptr := range []string{"/foo/2", "/foo/01", "/foo/-", "/missing"} {
+			if _, err := GetPointer(doc, ptr); !errors.Is(err, ErrNotFound) {
+				t.Errorf("GetPointer(%q) error = %v, want %v", ptr, err, ErrNotFound)
+			}
+		}
+	})
+
+	t.Run("TestNodeJSONPointer", func(t *testing.T) {
+		tree := map[string]any{
+			"users": []any{map[string]any{"a/b": "x", "m~n": "y"}},
+		}
+
+		want := map[string]string{
+			"x": "/users/0/a~1b",
+			"y": "/users/0/m~0n",
+		}
+		err := Visit(tree, func(n Node) Action {
+			if n.Value.Kind() != reflect.String {
+				return Continue
+			}
+
+			ptr := n.JSONPointer()
+			if ptr != want[n.Value.String()] {
+				t.Errorf("JSONPointer() = %s, want %s", ptr, want[n.Value.String()])
+			}
+			if got, err := GetPointer(tree, ptr); err != nil || got != n.Interface {
+				t.Errorf("GetPointer(%s) = (%v, %v), want %v", ptr, got, err, n.Interface)
+			}
+			return Continue
+		})
+		if err != nil {
+			t.Errorf("Visit() error = %v", err)
+		}
+	})
+}
@@ -1,89 +1,57 @@
 package gotree
 
-import (
-	"fmt"
-	"reflect"
-)
-
-// traverseHelper recursively traverses a node and collects values based on the
-// filter function. It handles maps, slices, arrays, structs, and interfaces
-// through type reflection. For each node, it either collects the value (if
-// filter returns true) or continues traversing deeper.
-func traverseHelper(node Node, filter FilterFunc) []Node {
-	results := make([]Node, 0)
-
-	switch node.Value.Kind() {
-	case reflect.Map:
-		// Iterate over all map keys
-		for _, k := range node.Value.MapKeys() {
-			strKey := fmt.Sprint(k.Interface())
-			newFullKey := strKey
-			if node.FullKey != "" {
-				newFullKey = node.FullKey + "." + strKey
-			}
-
-			v := node.Value.MapIndex(k)
-			childNode := newNode(newFullKey, strKey, v)
-			if filter(childNode) {
-				results = append(results, childNode)
-			} else {
-				results = append(results, traverseHelper(childNode, filter)...)
-			}
-		}
-	case reflect.Slice, reflect.Array:
-		// Iterate over all slice index
-		for i := 0; i < node.Value.Len(); i++ {
-			strKey := fmt.Sprintf("[%d]", i) // Array key format
-			newFullKey := strKey
-			if node.FullKey != "" {
-				newFullKey = node.FullKey + strKey
-			}
-
-			v := node.Value.Index(i)
-			childNode := newNode(newFullKey, strKey, v)
-			if filter(childNode) {
-				results = append(results, childNode)
-			} else {
-				results = append(results, traverseHelper(childNode, filter)...)
-			}
-		}
-	case reflect.Struct:
-		reflectType := node.Value.Type()
-		for i := 0; i < node.Value.NumField(); i++ {
-			field := reflectType.Field(i)
-			if !field.IsExported() {
-				continue
-			}
-
-			newFullKey := field.Name
-			if node.FullKey != "" {
-				newFullKey = node.FullKey + "." + field.Name
-			}
-
-			v := node.Value.Field(i)
-			childNode := newNode(newFullKey, field.Name, v)
-			if filter(childNode) {
-				results = append(results, childNode)
-			} else {
-				results = append(results, traverseHelper(childNode, filter)...)
-			}
+import "context"
+
+// traverseNodes walks the tree with w and collects the nodes that match the
+// filter function. For each node, it either collects the node (if filter
+// returns true) or continues traversing deeper. onMatch decides whether the
+// walk also descends into matched nodes.
+func (w *Walker) traverseNodes(tree any, filter FilterFunc, onMatch Action) ([]Node, error) {
+	return w.traverseNodesContext(context.Background(), tree, filter, onMatch)
+}
+
+// traverseNodesContext is traverseNodes, ending early with an error once ctx
+// is done.
+func (w *Walker) traverseNodesContext(ctx context.Context, tree any, filter FilterFunc, onMatch Action) ([]Node, error) {
+	nodes := make([]Node, 0)
+
+	err := w.VisitContext(ctx, tree, func(n Node) Action {
+		if !test(n, filter) || w.nestedMatch(n, filter, onMatch) {
+			return Continue
 		}
-	case reflect.Interface:
-		node.Value = node.Value.Elem()
-		results = append(results, traverseHelper(node, filter)...)
-	default:
-		node := newNode(node.FullKey, node.Key, node.Value)
-		if filter(node) {
-			results = append(results, node)
+		nodes = append(nodes, n)
+		return onMatch
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return nodes, nil
+}
+
+// nestedMatch reports whether the match n has to be dropped because it lies
+// below another match. This only happens in PostOrder, where SkipChildren
+// comes too late to keep the walk out of a matched node: every visited node
+// above n is tested with filter once more.
+func (w *Walker) nestedMatch(n Node, filter FilterFunc, onMatch Action) bool {
+	if onMatch != SkipChildren || w.opts.Order != PostOrder {
+		return false
+	}
+
+	for p := n.Parent; p != nil && p.Depth >= w.opts.MinDepth; p = p.Parent {
+		if test(*p, filter) {
+			return true
 		}
 	}
-	return results
+	return false
 }
 
 // Traverse traverses a nested JSON tree and returns all values for which the
-// filter function returns true. It initializes the traversal with the root
-// node of the data structure and then calls traverseHelper to perform the
-// actual recursive traversal.
+// filter function returns true. It walks the tree with a Walker and collects
+// matching nodes without descending into them.
 //
 // The filter function receives each node during traversal and determines
 // whether to include the node's value in the results.
@@ -95,15 +63,27 @@ func traverseHelper(node Node, filter FilterFunc) []Node {
 //
 // Returns:
 //   - The a slice matching value, or error if no match is found or tree is nil.
-func Traverse(tree any, filter FilterFunc) ([]any, error) {
-	if tree == nil {
-		return []any{}, ErrNilTree
+func Traverse(tree any, filter FilterFunc, opts ...Option) ([]any, error) {
+	nodes, err := NewWalker(opts...).traverseNodes(tree, filter, SkipChildren)
+	if err != nil {
+		return []any{}, err
 	}
-	node := newNode("", "", reflect.ValueOf(tree))
-	nodes := traverseHelper(node, filter)
 
-	if len(nodes) == 0 {
-		return []any{}, ErrNotFound
+	values := make([]any, len(nodes))
+	for i, v := range nodes {
+		values[i] = v.Interface
+	}
+	return values, nil
+}
+
+// TraverseAll works like Traverse, but keeps descending into matched nodes, so
+// matches nested inside other matches are returned as well. Parents come
+// before their children in the result. This suits recursive queries such as
+// every node named "children" in a tree-shaped document.
+func TraverseAll(tree any, filter FilterFunc, opts ...Option) ([]any, error) {
+	nodes, err := NewWalker(opts...).traverseNodes(tree, filter, Continue)
+	if err != nil {
+		return []any{}, err
 	}
 
 	values := make([]any, len(nodes))
@@ -115,16 +95,10 @@ func Traverse(tree any, filter FilterFunc) ([]any, error) {
 
 // TraverseString searches for all string values in the tree that match the
 // filter. Returns a slice of matching string values and an error if none found.
-func TraverseString(tree any, filter FilterFunc) ([]string, error) {
-	if tree == nil {
-		return nil, ErrNilTree
-	}
-
-	node := newNode("", "", reflect.ValueOf(tree))
-	nodes := traverseHelper(node, FilterString(filter))
-
-	if len(nodes) == 0 {
-		return nil, ErrNotFound
+func TraverseString(tree any, filter FilterFunc, opts ...Option) ([]string, error) {
+	nodes, err := NewWalker(opts...).traverseNodes(tree, FilterString(filter), SkipChildren)
+	if err != nil {
+		return nil, err
 	}
 
 	values := make([]string, len(nodes))
@@ -138,16 +112,10 @@ func TraverseString(tree any, filter FilterFunc) ([]string, error) {
 // TraverseBool searches for all boolean values in the tree that match the
 // filter. Returns a slice of matching boolean values and an error if none
 // found.
-func TraverseBool(tree any, filter FilterFunc) ([]bool, error) {
-	if tree == nil {
-		return nil, ErrNilTree
-	}
-
-	node := newNode("", "", reflect.ValueOf(tree))
-	nodes := traverseHelper(node, FilterBool(filter))
-
-	if len(nodes) == 0 {
-		return nil, ErrNotFound
+func TraverseBool(tree any, filter FilterFunc, opts ...Option) ([]bool, error) {
+	nodes, err := NewWalker(opts...).traverseNodes(tree, FilterBool(filter), SkipChildren)
+	if err != nil {
+		return nil, err
 	}
 
 	values := make([]bool, len(nodes))
@@ -161,16 +129,10 @@ func TraverseBool(tree any, filter FilterFunc) ([]bool, error) {
 // TraverseInt searches for all integer values in the tree that match the
 // filter. Returns a slice of matching integer values and an error if none
 // found.
-func TraverseInt(tree any, filter FilterFunc) ([]int64, error) {
-	if tree == nil {
-		return nil, ErrNilTree
-	}
-
-	node := newNode("", "", reflect.ValueOf(tree))
-	nodes := traverseHelper(node, FilterInt(filter))
-
-	if len(nodes) == 0 {
-		return nil, ErrNotFound
+func TraverseInt(tree any, filter FilterFunc, opts ...Option) ([]int64, error) {
+	nodes, err := NewWalker(opts...).traverseNodes(tree, FilterInt(filter), SkipChildren)
+	if err != nil {
+		return nil, err
 	}
 
 	values := make([]int64, len(nodes))
@@ -184,16 +146,10 @@ func TraverseInt(tree any, filter FilterFunc) ([]int64, error) {
 // TraverseUint searches for all unsigned integer values in the tree that match
 // the filter. Returns a slice of matching unsigned integer values and an error
 // if none found.
-func TraverseUint(tree any, filter FilterFunc) ([]uint64, error) {
-	if tree == nil {
-		return nil, ErrNilTree
-	}
-
-	node := newNode("", "", reflect.ValueOf(tree))
-	nodes := traverseHelper(node, FilterUint(filter))
-
-	if len(nodes) == 0 {
-		return nil, ErrNotFound
+func TraverseUint(tree any, filter FilterFunc, opts ...Option) ([]uint64, error) {
+	nodes, err := NewWalker(opts...).traverseNodes(tree, FilterUint(filter), SkipChildren)
+	if err != nil {
+		return nil, err
 	}
 
 	values := make([]uint64, len(nodes))
@@ -207,21 +163,47 @@ func TraverseUint(tree any, filter FilterFunc) ([]uint64, error) {
 // TraverseFloat searches for all floating point values in the tree that match
 // the filter. Returns a slice of matching float values and an error if none
 // found.
-func TraverseFloat(tree any, filter FilterFunc) ([]float64, error) {
-	if tree == nil {
-		return nil, ErrNilTree
+func TraverseFloat(tree any, filter FilterFunc, opts ...Option) ([]float64, error) {
+	nodes, err := NewWalker(opts...).traverseNodes(tree, FilterFloat(filter), SkipChildren)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]float64, len(nodes))
+	for i, v := range nodes {
+		values[i] = v.Value.Float()
 	}
 
-	node := newNode("", "", reflect.ValueOf(tree))
-	nodes := traverseHelper(node, FilterFloat(filter))
+	return values, nil
+}
 
-	if len(nodes) == 0 {
-		return nil, ErrNotFound
+// TraverseMaps searches for all map[string]any values in the tree that match
+// the filter. Returns a slice of matching maps and an error if none found.
+func TraverseMaps(tree any, filter FilterFunc, opts ...Option) ([]map[string]any, error) {
+	nodes, err := NewWalker(opts...).traverseNodes(tree, filterType(mapType, filter), SkipChildren)
+	if err != nil {
+		return nil, err
 	}
 
-	values := make([]float64, len(nodes))
+	values := make([]map[string]any, len(nodes))
 	for i, v := range nodes {
-		values[i] = v.Value.Float()
+		values[i] = v.Value.Interface().(map[string]any)
+	}
+
+	return values, nil
+}
+
+// TraverseSlices searches for all []any values in the tree that match the
+// filter. Returns a slice of matching slices and an error if none found.
+func TraverseSlices(tree any, filter FilterFunc, opts ...Option) ([][]any, error) {
+	nodes, err := NewWalker(opts...).traverseNodes(tree, filterType(sliceType, filter), SkipChildren)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([][]any, len(nodes))
+	for i, v := range nodes {
+		values[i] = v.Value.Interface().([]any)
 	}
 
 	return values, nil
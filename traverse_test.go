@@ -464,4 +464,133 @@ func TestTraverseFunctions(t *testing.T) {
 			})
 		}
 	})
+	t.Run("TestTraversePointers", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			tree      any
+			filter    func(Node) bool
+			want      []int64
+			wantFound bool
+		}{
+			{
+				name:      "Traverse ints behind pointers",
+				tree:      pointerData,
+				filter:    NoneFilter,
+				want:      []int64{41, 35},
+				wantFound: true,
+			},
+			{
+				name: "Traverse ints by key",
+				tree: pointerData,
+				filter: func(n Node) bool {
+					return n.Key == "Age"
+				},
+				want:      []int64{41, 35},
+				wantFound: true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := TraverseInt(tt.tree, tt.filter)
+
+				if (err == nil) != tt.wantFound {
+					t.Errorf("TraverseInt() found = %v, want %v", err, tt.wantFound)
+					return
+				}
+
+				if !EqualSlices(t, tt.want, got) {
+					t.Errorf("TraverseInt() = (%+v), want = (%+v)", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("TestTraverseAll", func(t *testing.T) {
+		doc := map[string]any{
+			"name": "root",
+			"children": []any{
+				map[string]any{
+					"name": "a",
+					"children": []any{
+						map[string]any{"name": "a1", "children": []any{}},
+					},
+				},
+				map[string]any{"name": "b", "children": []any{}},
+			},
+		}
+		isChildren := func(n Node) bool {
+			return n.Key == "children"
+		}
+
+		got, err := TraverseAll(doc, isChildren)
+		if err != nil || len(got) != 4 {
+			t.Errorf("TraverseAll() = (%v, %v), want 4 nodes", got, err)
+		}
+
+		got, err = Traverse(doc, isChildren)
+		if err != nil || len(got) != 1 {
+			t.Errorf("Traverse() = (%v, %v), want 1 node", got, err)
+		}
+
+		names, err := TraverseAll(doc, func(n Node) bool {
+			return n.Key == "name"
+		})
+		want := []any{"root", "a", "a1", "b"}
+		if err != nil || !EqualSlices(t, want, names) {
+			t.Errorf("TraverseAll() = (%v, %v), want %v", names, err, want)
+		}
+
+		// Matches nested in other matches are left out whatever the order
+		for _, order := range []Order{PreOrder, PostOrder, BreadthFirst} {
+			got, err = Traverse(doc, isChildren, WithOrder(order))
+			if err != nil || len(got) != 1 {
+				t.Errorf("Traverse(order %d) = (%v, %v), want 1 node", order, got, err)
+			}
+
+			count := 0
+			Matches(doc, isChildren, WithOrder(order))(func(Node) bool {
+				count++
+				return true
+			})
+			if count != 1 {
+				t.Errorf("Matches(order %d) yielded %d nodes, want 1", order, count)
+			}
+		}
+
+		got, err = TraverseAll(doc, isChildren, WithOrder(PostOrder))
+		if err != nil || len(got) != 4 {
+			t.Errorf("TraverseAll(PostOrder) = (%v, %v), want 4 nodes", got, err)
+		}
+
+		// Without a visited ancestor to stop at, the nested names are kept
+		deep, err := TraverseString(doc, KeyFilter("name"),
+			WithOrder(PostOrder), WithMinDepth(3))
+		if err != nil || !EqualSlices(t, []string{"a", "b", "a1"}, deep) {
+			t.Errorf("TraverseString(PostOrder) = (%v, %v), want [a b a1]", deep, err)
+		}
+
+		if _, err := TraverseAll(nil, isChildren); err != ErrNilTree {
+			t.Errorf("TraverseAll(nil) error = %v, want %v", err, ErrNilTree)
+		}
+	})
+
+	t.Run("TestTraverseContainers", func(t *testing.T) {
+		maps, err := TraverseMaps(testData, NoneFilter, WithKeyOrder(LexicalOrder))
+		if err != nil || len(maps) != 4 {
+			t.Fatalf("TraverseMaps() = (%v, %v), want 4 maps", maps, err)
+		}
+		if maps[0]["array_nested_int"] != 999 || maps[1]["deep_int"] != 100 {
+			t.Errorf("TraverseMaps() = %v, want array[5], nested and users", maps)
+		}
+
+		slices, err := TraverseSlices(testData, NoneFilter)
+		if err != nil || len(slices) != 1 {
+			t.Errorf("TraverseSlices() = (%v, %v), want 1 slice", slices, err)
+		}
+
+		if _, err := TraverseMaps(testData, KeyFilter("missing")); err != ErrNotFound {
+			t.Errorf("TraverseMaps() error = %v, want %v", err, ErrNotFound)
+		}
+	})
 }
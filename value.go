@@ -0,0 +1,152 @@
+package gotree
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ValueEquals returns a FilterFunc that checks if a node's value equals x.
+// Numbers are compared by value across all integer, unsigned and float kinds,
+// so ValueEquals(1) accepts int8(1), uint(1) and 1.0 alike. A nil x accepts
+// nil nodes, and any other x is compared with reflect.DeepEqual to both the
+// node's raw and resolved value.
+func ValueEquals(x any) FilterFunc {
+	if x == nil {
+		return func(n Node) bool {
+			return n.IsNil()
+		}
+	}
+
+	xv := reflect.ValueOf(x)
+	switch {
+	case isInt(xv):
+		i := xv.Int()
+		return numberFilter(
+			func(v int64) bool { return v == i },
+			func(v uint64) bool { return i >= 0 && v == uint64(i) },
+			func(v float64) bool { return v == float64(i) },
+		)
+	case isUint(xv):
+		u := xv.Uint()
+		return numberFilter(
+			func(v int64) bool { return v >= 0 && uint64(v) == u },
+			func(v uint64) bool { return v == u },
+			func(v float64) bool { return v == float64(u) },
+		)
+	case isFloat(xv):
+		f := xv.Float()
+		return numberFilter(
+			func(v int64) bool { return float64(v) == f },
+			func(v uint64) bool { return float64(v) == f },
+			func(v float64) bool { return v == f },
+		)
+	}
+
+	return func(n Node) bool {
+		if reflect.DeepEqual(n.Interface, x) {
+			return true
+		}
+		return n.Value.IsValid() && n.Value.CanInterface() &&
+			reflect.DeepEqual(n.Value.Interface(), x)
+	}
+}
+
+// NumberBetween returns a FilterFunc that checks if a node's value is a number
+// of any integer, unsigned or float kind between lo and hi, inclusive.
+// Integers are converted to float64 for the comparison.
+func NumberBetween(lo, hi float64) FilterFunc {
+	between := func(f float64) bool {
+		return lo <= f && f <= hi
+	}
+	return numberFilter(
+		func(v int64) bool { return between(float64(v)) },
+		func(v uint64) bool { return between(float64(v)) },
+		between,
+	)
+}
+
+// numberFilter returns a FilterFunc that accepts integer, unsigned and float
+// nodes whose value passes the test for their kind.
+func numberFilter(ints func(int64) bool, uints func(uint64) bool, floats func(float64) bool) FilterFunc {
+	filterInt := FilterInt(func(n Node) bool { return ints(n.Value.Int()) })
+	filterUint := FilterUint(func(n Node) bool { return uints(n.Value.Uint()) })
+	filterFloat := FilterFloat(func(n Node) bool { return floats(n.Value.Float()) })
+
+	return func(n Node) bool {
+		return filterInt(n) || filterUint(n) || filterFloat(n)
+	}
+}
+
+// StringContains returns a FilterFunc that checks if a node's value is a
+// string containing substr.
+func StringContains(substr string) FilterFunc {
+	return FilterString(func(n Node) bool {
+		return strings.Contains(n.Value.String(), substr)
+	})
+}
+
+// StringHasPrefix returns a FilterFunc that checks if a node's value is a
+// string starting with prefix.
+func StringHasPrefix(prefix string) FilterFunc {
+	return FilterString(func(n Node) bool {
+		return strings.HasPrefix(n.Value.String(), prefix)
+	})
+}
+
+// StringHasSuffix returns a FilterFunc that checks if a node's value is a
+// string ending with suffix.
+func StringHasSuffix(suffix string) FilterFunc {
+	return FilterString(func(n Node) bool {
+		return strings.HasSuffix(n.Value.String(), suffix)
+	})
+}
+
+// TimeBefore returns a FilterFunc that checks if a node's value is a time
+// before t. Both time.Time values and strings in RFC 3339 format, as found in
+// decoded JSON, are accepted.
+func TimeBefore(t time.Time) FilterFunc {
+	return filterTime(func(v time.Time) bool {
+		return v.Before(t)
+	})
+}
+
+// TimeAfter returns a FilterFunc that checks if a node's value is a time after
+// t. Like TimeBefore, it accepts time.Time values and RFC 3339 strings.
+func TimeAfter(t time.Time) FilterFunc {
+	return filterTime(func(v time.Time) bool {
+		return v.After(t)
+	})
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// filterTime returns a FilterFunc that accepts the nodes holding a time that
+// passes test.
+func filterTime(test func(time.Time) bool) FilterFunc {
+	return func(n Node) bool {
+		switch {
+		case n.Value.Kind() == reflect.String:
+			t, err := time.Parse(time.RFC3339, n.Value.String())
+			return err == nil && test(t)
+		case n.Value.IsValid() && n.Value.Type() == timeType:
+			return test(n.Value.Interface().(time.Time))
+		default:
+			return false
+		}
+	}
+}
+
+// LenGreaterThan returns a FilterFunc that checks if a node's value is a
+// slice, array, map or string holding more than l elements. The length of a
+// string is counted in bytes.
+func LenGreaterThan(l int) FilterFunc {
+	return func(n Node) bool {
+		switch n.Value.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
+			return n.Value.Len() > l
+		default:
+			return false
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package gotree
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValueFilters(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter FilterFunc
+		value  any
+		want   bool
+	}{
+		{name: "ValueEquals int", filter: ValueEquals(1), value: int8(1), want: true},
+		{name: "ValueEquals int uint", filter: ValueEquals(1), value: uint(1), want: true},
+		{name: "ValueEquals int float", filter: ValueEquals(1), value: 1.0, want: true},
+		{name: "ValueEquals negative uint", filter: ValueEquals(-1), value: uint64(1<<64 - 1), want: false},
+		{name: "ValueEquals uint", filter: ValueEquals(uint(2)), value: 2, want: true},
+		{name: "ValueEquals float", filter: ValueEquals(2.5), value: float32(2.5), want: true},
+		{name: "ValueEquals float int", filter: ValueEquals(2.5), value: 2, want: false},
+		{name: "ValueEquals string", filter: ValueEquals("a"), value: "a", want: true},
+		{name: "ValueEquals string pointer", filter: ValueEquals("a"), value: ptr("a"), want: true},
+		{name: "ValueEquals string number", filter: ValueEquals("1"), value: 1, want: false},
+		{name: "ValueEquals bool", filter: ValueEquals(true), value: true, want: true},
+		{name: "ValueEquals slice", filter: ValueEquals([]int{1}), value: []int{1}, want: true},
+		{name: "ValueEquals nil", filter: ValueEquals(nil), value: (*int)(nil), want: true},
+		{name: "ValueEquals not nil", filter: ValueEquals(nil), value: 0, want: false},
+		{name: "NumberBetween int", filter: NumberBetween(1, 3), value: 3, want: true},
+		{name: "NumberBetween uint", filter: NumberBetween(1, 3), value: uint16(4), want: false},
+		{name: "NumberBetween float", filter: NumberBetween(1, 3), value: 1.5, want: true},
+		{name: "NumberBetween string", filter: NumberBetween(1, 3), value: "2", want: false},
+		{name: "StringContains", filter: StringContains("ell"), value: "hello", want: true},
+		{name: "StringContains number", filter: StringContains("1"), value: 1, want: false},
+		{name: "StringHasPrefix", filter: StringHasPrefix("he"), value: "hello", want: true},
+		{name: "StringHasSuffix", filter: StringHasSuffix("he"), value: "hello", want: false},
+		{name: "TimeBefore", filter: TimeBefore(day), value: day.Add(-time.Hour), want: true},
+		{name: "TimeBefore pointer", filter: TimeBefore(day), value: ptr(day), want: false},
+		{name: "TimeBefore string", filter: TimeBefore(day), value: "2024-04-30T12:00:00Z", want: true},
+		{name: "TimeBefore bad string", filter: TimeBefore(day), value: "yesterday", want: false},
+		{name: "TimeAfter", filter: TimeAfter(day), value: day.Add(time.Hour), want: true},
+		{name: "LenGreaterThan slice", filter: LenGreaterThan(1), value: []int{1, 2}, want: true},
+		{name: "LenGreaterThan map", filter: LenGreaterThan(1), value: map[int]int{1: 1}, want: false},
+		{name: "LenGreaterThan string", filter: LenGreaterThan(1), value: "ab", want: true},
+		{name: "LenGreaterThan int", filter: LenGreaterThan(1), value: 10, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := newNode("v", "v", reflect.ValueOf(tt.value))
+			if got := tt.filter(n); got != tt.want {
+				t.Errorf("filter(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("TestValueFiltersTraverse", func(t *testing.T) {
+		data := map[string]any{
+			"users": []any{
+				map[string]any{"name": "Ann", "age": 31},
+				map[string]any{"name": "Bob", "age": 17.5},
+			},
+		}
+
+		got, err := Traverse(data, NumberBetween(18, 65))
+		want := []any{31}
+		if err != nil || !EqualSlices(t, want, got) {
+			t.Errorf("Traverse() = (%v, %v), want %v", got, err, want)
+		}
+	})
+}
@@ -0,0 +1,309 @@
+package gotree
+
+import (
+	"context"
+	"reflect"
+)
+
+// Action tells the walker how to continue after a node has been visited.
+type Action int
+
+const (
+	// Continue descends into the children of the node.
+	Continue Action = iota
+
+	// SkipChildren moves on to the next sibling without descending into the
+	// node, e.g. to prune a large subtree that is of no interest.
+	SkipChildren
+
+	// Stop ends the walk.
+	Stop
+)
+
+// VisitFunc is called by the walker for every node. The returned Action
+// decides whether the walker descends into the node, skips its children or
+// stops altogether.
+type VisitFunc func(Node) Action
+
+// Walker walks a tree and offers every node to a visit function, depth-first
+// in pre-order unless configured with WithOrder. Find, Traverse and Has are
+// built on top of it. A Walker only holds its Options, so it can be reused
+// and shared between goroutines.
+type Walker struct {
+	opts Options
+}
+
+// NewWalker returns a Walker configured by opts.
+func NewWalker(opts ...Option) *Walker {
+	return &Walker{opts: newOptions(opts)}
+}
+
+// Visit calls visit for every node of tree in the order set by WithOrder,
+// starting with the root itself unless the order is PostOrder, and follows the
+// Action it returns for each node.
+//
+// Visit returns ErrNilTree if tree is nil, and a *PathError wrapping ErrCycle
+// when the walker is configured with CycleError and reaches a cycle.
+func (w *Walker) Visit(tree any, visit VisitFunc) error {
+	return w.VisitContext(context.Background(), tree, visit)
+}
+
+// Visit calls visit for every node of tree. It is a shorthand for
+// NewWalker(opts...).Visit(tree, visit).
+func Visit(tree any, visit VisitFunc, opts ...Option) error {
+	return NewWalker(opts...).Visit(tree, visit)
+}
+
+// Walk calls visit for every node of tree in the order set by WithOrder. The
+// walk ends as soon as visit returns false. It returns the same errors as
+// Visit.
+func (w *Walker) Walk(tree any, visit func(Node) bool) error {
+	return w.Visit(tree, func(n Node) Action {
+		if !visit(n) {
+			return Stop
+		}
+		return Continue
+	})
+}
+
+// Walk calls visit for every node of tree. It is a shorthand for
+// NewWalker(opts...).Walk(tree, visit).
+func Walk(tree any, visit func(Node) bool, opts ...Option) error {
+	return NewWalker(opts...).Walk(tree, visit)
+}
+
+// Visitor is told when the walker enters a node and when it leaves it again,
+// after every node below it has been walked. This suits work that brackets
+// the children of a node, such as pretty-printing or computing subtree sizes.
+type Visitor interface {
+	// Enter is called before the nodes below n are walked. Its Action decides
+	// whether they are walked at all; with SkipChildren, Leave follows right
+	// away.
+	Enter(n Node) Action
+
+	// Leave is called once the nodes below n have been walked. Returning
+	// Stop ends the walk, any other Action continues it.
+	Leave(n Node) Action
+}
+
+// WalkVisitor walks tree depth-first and calls v.Enter and v.Leave around
+// every node, starting with the root itself. The Order option does not apply.
+// It returns the same errors as Visit.
+func (w *Walker) WalkVisitor(tree any, v Visitor) error {
+	if tree == nil {
+		return ErrNilTree
+	}
+
+	s := newWalkState(w.opts)
+	s.walkAround(newNode("", "", reflect.ValueOf(tree)), v.Enter, v.Leave)
+	return s.err
+}
+
+// WalkVisitor walks tree depth-first and calls v.Enter and v.Leave around
+// every node. It is a shorthand for NewWalker(opts...).WalkVisitor(tree, v).
+func WalkVisitor(tree any, v Visitor, opts ...Option) error {
+	return NewWalker(opts...).WalkVisitor(tree, v)
+}
+
+// walkState carries the options and bookkeeping of a single walk.
+type walkState struct {
+	opts Options
+
+	// visiting holds the pointers, maps and slices between the root and the
+	// node currently being walked. It is nil in a breadth-first walk.
+	visiting map[visitKey]struct{}
+
+	// ancestors holds the pointers, maps and slices above the root of the
+	// walk when it covers a subtree for ParallelTraverse. It is shared and
+	// never modified.
+	ancestors map[visitKey]struct{}
+
+	// err is set when the walk has to stop early.
+	err error
+
+	// ctx is checked every ctxCheckInterval nodes, unless it is nil.
+	ctx   context.Context
+	nodes int
+}
+
+// newWalkState creates the state for a walk configured by opts.
+func newWalkState(opts Options) *walkState {
+	return &walkState{
+		opts:     opts,
+		visiting: make(map[visitKey]struct{}),
+	}
+}
+
+// run walks the tree below root in the order set by the options.
+func (s *walkState) run(root Node, visit VisitFunc) {
+	switch s.opts.Order {
+	case PostOrder:
+		s.walkAround(root, nil, visit)
+	case BreadthFirst:
+		s.walkBreadth(root, visit)
+	default:
+		s.walk(root, visit)
+	}
+}
+
+// walk visits node and then, unless visit says otherwise, every node below
+// it. It returns Stop once the walk has to end.
+func (s *walkState) walk(node Node, visit VisitFunc) Action {
+	return s.walkAround(node, visit, nil)
+}
+
+// walkAround walks node and every node below it depth-first, calling enter
+// before the nodes below a node are walked and leave after. Either may be nil.
+// It returns Stop once the walk has to end.
+func (s *walkState) walkAround(node Node, enter, leave VisitFunc) Action {
+	if s.canceled(node) {
+		return Stop
+	}
+
+	// Nodes above MinDepth are walked through without being visited
+	visible := node.Depth >= s.opts.MinDepth
+
+	descend := hasChildren(node.Value) && !s.atMaxDepth(node)
+	if visible && enter != nil {
+		switch enter(node) {
+		case Stop:
+			return Stop
+		case SkipChildren:
+			descend = false
+		}
+	}
+
+	if descend {
+		if s.enter(node) {
+			ok := s.eachChild(node, func(child Node) bool {
+				return s.walkAround(child, enter, leave) != Stop
+			})
+			s.leave(node)
+			if !ok {
+				return Stop
+			}
+		} else if s.err != nil {
+			return Stop
+		}
+	}
+
+	if visible && leave != nil && leave(node) == Stop {
+		return Stop
+	}
+	return Continue
+}
+
+// walkBreadth visits root and every node below it level by level.
+func (s *walkState) walkBreadth(root Node, visit VisitFunc) {
+	// Nodes are not walked one path at a time, so cycles are found through
+	// the Parent chain
+	s.visiting = nil
+
+	queue := []Node{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue[0] = Node{}
+		queue = queue[1:]
+
+		if s.canceled(node) {
+			return
+		}
+
+		if node.Depth >= s.opts.MinDepth {
+			switch visit(node) {
+			case Stop:
+				return
+			case SkipChildren:
+				continue
+			}
+		}
+
+		if !hasChildren(node.Value) || s.atMaxDepth(node) {
+			continue
+		}
+		if !s.enter(node) {
+			if s.err != nil {
+				return
+			}
+			continue
+		}
+
+		s.eachChild(node, func(child Node) bool {
+			queue = append(queue, child)
+			return true
+		})
+	}
+}
+
+// atMaxDepth reports whether the children of node lie beyond MaxDepth.
+func (s *walkState) atMaxDepth(node Node) bool {
+	return s.opts.MaxDepth > 0 && node.Depth >= s.opts.MaxDepth
+}
+
+// eachChild calls fn for every child of node: map entries, slice and array
+// elements, and exported struct fields. It returns false as soon as fn does.
+func (s *walkState) eachChild(node Node, fn func(Node) bool) bool {
+	value := node.Value
+	parent := &node
+
+	switch value.Kind() {
+	case reflect.Map:
+		for _, k := range s.mapKeys(value) {
+			if !fn(parent.mapChild(k, value.MapIndex(k))) {
+				return false
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if !fn(parent.indexChild(i, value.Index(i))) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		reflectType := value.Type()
+		for i := 0; i < value.NumField(); i++ {
+			field := reflectType.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			if !fn(parent.fieldChild(field.Name, value.Field(i))) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// hasChildren reports whether the walker descends below value. A non-nil
+// pointer is only left unresolved when it leads back to itself, so it is
+// entered to report the cycle.
+func hasChildren(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		return true
+	case reflect.Pointer:
+		return !value.IsNil()
+	default:
+		return false
+	}
+}
+
+// isBranch reports whether value is a map, slice, array or struct.
+func isBranch(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		return true
+	default:
+		return false
+	}
+}
+
+// test reports whether filter accepts node. As Find, Traverse and Has have
+// always done, the root of the tree is only tested when it is a leaf.
+func test(node Node, filter FilterFunc) bool {
+	if node.Depth == 0 && isBranch(node.Value) {
+		return false
+	}
+	return filter(node)
+}
@@ -0,0 +1,389 @@
+package gotree
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWalker(t *testing.T) {
+	tree := map[string]any{
+		"a": []any{1, map[string]any{"b": "x"}},
+		"c": &testAddress{City: ptr("Oslo")},
+	}
+
+	t.Run("TestWalkOrder", func(t *testing.T) {
+		var keys []string
+		err := Walk(tree, func(n Node) bool {
+			keys = append(keys, n.FullKey)
+			return true
+		}, WithKeyOrder(LexicalOrder))
+		if err != nil {
+			t.Fatalf("Walk() error = %v", err)
+		}
+
+		want := []string{"", "a", "a[0]", "a[1]", "a[1].b", "c", "c.City", "c.Zip"}
+		if !reflect.DeepEqual(keys, want) {
+			t.Errorf("Walk() visited %v, want %v", keys, want)
+		}
+	})
+
+	t.Run("TestWalkStop", func(t *testing.T) {
+		count := 0
+		err := Walk(tree, func(n Node) bool {
+			count++
+			return n.FullKey != "a[0]"
+		}, WithKeyOrder(LexicalOrder))
+		if err != nil || count != 3 {
+			t.Errorf("Walk() = (%d nodes, %v), want 3 nodes", count, err)
+		}
+	})
+
+	t.Run("TestWalkResolvesValues", func(t *testing.T) {
+		w := NewWalker(WithKeyOrder(LexicalOrder))
+		err := w.Walk(tree, func(n Node) bool {
+			switch n.FullKey {
+			case "a[1]":
+				if n.Value.Kind() != reflect.Map {
+					t.Errorf("a[1] kind = %v, want map", n.Value.Kind())
+				}
+			case "c":
+				if _, ok := n.Interface.(*testAddress); !ok {
+					t.Errorf("c interface = %T, want *testAddress", n.Interface)
+				}
+				if n.Value.Kind() != reflect.Struct {
+					t.Errorf("c kind = %v, want struct", n.Value.Kind())
+				}
+			case "c.City":
+				if n.Value.String() != "Oslo" {
+					t.Errorf("c.City = %v, want Oslo", n.Value)
+				}
+			}
+			return true
+		})
+		if err != nil {
+			t.Errorf("Walk() error = %v", err)
+		}
+	})
+
+	t.Run("TestWalkErrors", func(t *testing.T) {
+		if err := Walk(nil, func(Node) bool { return true }); err != ErrNilTree {
+			t.Errorf("Walk(nil) error = %v, want %v", err, ErrNilTree)
+		}
+
+		var loop any
+		loop = &loop
+		err := Walk(loop, func(Node) bool { return true },
+			WithCycleMode(CycleError))
+		if !errors.Is(err, ErrCycle) {
+			t.Errorf("Walk() error = %v, want %v", err, ErrCycle)
+		}
+	})
+
+	t.Run("TestVisitActions", func(t *testing.T) {
+		data := map[string]any{
+			"blobs": []any{"b1", "b2", "b3"},
+			"meta":  map[string]any{"name": "doc", "size": 3},
+			"tail":  "end",
+		}
+
+		tests := []struct {
+			name  string
+			visit func(Node) Action
+			want  []string
+		}{
+			{
+				name: "Continue everywhere",
+				visit: func(n Node) Action {
+					return Continue
+				},
+				want: []string{"", "blobs", "blobs[0]", "blobs[1]", "blobs[2]",
+					"meta", "meta.name", "meta.size", "tail"},
+			},
+			{
+				name: "Skip a subtree",
+				visit: func(n Node) Action {
+					if n.Key == "blobs" {
+						return SkipChildren
+					}
+					return Continue
+				},
+				want: []string{"", "blobs", "meta", "meta.name", "meta.size",
+					"tail"},
+			},
+			{
+				name: "Stop inside a subtree",
+				visit: func(n Node) Action {
+					if n.FullKey == "meta.name" {
+						return Stop
+					}
+					return Continue
+				},
+				want: []string{"", "blobs", "blobs[0]", "blobs[1]", "blobs[2]",
+					"meta", "meta.name"},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var keys []string
+				err := Visit(data, func(n Node) Action {
+					keys = append(keys, n.FullKey)
+					return tt.visit(n)
+				}, WithKeyOrder(LexicalOrder))
+				if err != nil {
+					t.Fatalf("Visit() error = %v", err)
+				}
+				if !reflect.DeepEqual(keys, tt.want) {
+					t.Errorf("Visit() visited %v, want %v", keys, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("TestDepthLimits", func(t *testing.T) {
+		data := map[string]any{
+			"name": "top",
+			"user": map[string]any{
+				"name":    "mid",
+				"address": map[string]any{"name": "deep"},
+			},
+		}
+
+		tests := []struct {
+			name string
+			opts []Option
+			want []string
+		}{
+			{
+				name: "No limits",
+				want: []string{"top", "mid", "deep"},
+			},
+			{
+				name: "Max depth",
+				opts: []Option{WithMaxDepth(2)},
+				want: []string{"top", "mid"},
+			},
+			{
+				name: "Min depth",
+				opts: []Option{WithMinDepth(2)},
+				want: []string{"mid", "deep"},
+			},
+			{
+				name: "Min and max depth",
+				opts: []Option{WithMinDepth(2), WithMaxDepth(2)},
+				want: []string{"mid"},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := TraverseString(data, KeyFilter("name"), tt.opts...)
+				if err != nil || !EqualSlices(t, tt.want, got) {
+					t.Errorf("TraverseString() = (%v, %v), want %v", got, err, tt.want)
+				}
+			})
+		}
+
+		err := Visit(data, func(n Node) Action {
+			if n.Depth > 1 {
+				t.Errorf("Visit() reached %q at depth %d", n.FullKey, n.Depth)
+			}
+			return Continue
+		}, WithMaxDepth(1))
+		if err != nil {
+			t.Errorf("Visit() error = %v", err)
+		}
+
+		if Has(data, KeyFilter("address"), WithMaxDepth(1)) {
+			t.Errorf("Has() = true, want false")
+		}
+		if _, err := FindString(data, KeyFilter("name"), WithMinDepth(3)); err != nil {
+			t.Errorf("FindString() error = %v", err)
+		}
+	})
+
+	t.Run("TestWalkOrders", func(t *testing.T) {
+		tests := []struct {
+			name string
+			opts []Option
+			want []string
+		}{
+			{
+				name: "Pre-order",
+				opts: []Option{WithOrder(PreOrder)},
+				want: []string{"", "a", "a[0]", "a[1]", "a[1].b", "c", "c.City", "c.Zip"},
+			},
+			{
+				name: "Post-order",
+				opts: []Option{WithOrder(PostOrder)},
+				want: []string{"a[0]", "a[1].b", "a[1]", "a", "c.City", "c.Zip", "c", ""},
+			},
+			{
+				name: "Breadth-first",
+				opts: []Option{WithOrder(BreadthFirst)},
+				want: []string{"", "a", "c", "a[0]", "a[1]", "c.City", "c.Zip", "a[1].b"},
+			},
+			{
+				name: "Breadth-first depth limits",
+				opts: []Option{WithOrder(BreadthFirst), WithMinDepth(1), WithMaxDepth(1)},
+				want: []string{"a", "c"},
+			},
+			{
+				name: "Post-order min depth",
+				opts: []Option{WithOrder(PostOrder), WithMinDepth(2)},
+				want: []string{"a[0]", "a[1].b", "a[1]", "c.City", "c.Zip"},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var keys []string
+				opts := append([]Option{WithKeyOrder(LexicalOrder)}, tt.opts...)
+				err := Walk(tree, func(n Node) bool {
+					keys = append(keys, n.FullKey)
+					return true
+				}, opts...)
+				if err != nil || !reflect.DeepEqual(keys, tt.want) {
+					t.Errorf("Walk() = (%v, %v), want %v", keys, err, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("TestWalkOrderActions", func(t *testing.T) {
+		var keys []string
+		err := Visit(tree, func(n Node) Action {
+			keys = append(keys, n.FullKey)
+			switch n.FullKey {
+			case "a":
+				return SkipChildren
+			case "c.City":
+				return Stop
+			}
+			return Continue
+		}, WithKeyOrder(LexicalOrder), WithOrder(BreadthFirst))
+
+		want := []string{"", "a", "c", "c.City"}
+		if err != nil || !reflect.DeepEqual(keys, want) {
+			t.Errorf("Visit() = (%v, %v), want %v", keys, err, want)
+		}
+
+		// Subtree sizes, computed bottom-up
+		sizes := make(map[string]int)
+		Visit(tree, func(n Node) Action {
+			sizes[n.FullKey]++
+			if n.Parent != nil {
+				sizes[n.Parent.FullKey] += sizes[n.FullKey]
+			}
+			return SkipChildren
+		}, WithOrder(PostOrder))
+		if sizes["a"] != 4 || sizes[""] != 8 {
+			t.Errorf("subtree sizes = %v, want a: 4, root: 8", sizes)
+		}
+	})
+
+	t.Run("TestFindShallowest", func(t *testing.T) {
+		data := map[string]any{
+			"a": map[string]any{"b": map[string]any{"id": "deep"}},
+			"z": map[string]any{"id": "shallow"},
+		}
+
+		got, err := FindString(data, KeyFilter("id"), WithKeyOrder(LexicalOrder))
+		if err != nil || got != "deep" {
+			t.Errorf("FindString() = (%v, %v), want deep", got, err)
+		}
+
+		got, err = FindString(data, KeyFilter("id"),
+			WithKeyOrder(LexicalOrder), WithOrder(BreadthFirst))
+		if err != nil || got != "shallow" {
+			t.Errorf("FindString() = (%v, %v), want shallow", got, err)
+		}
+	})
+
+	t.Run("TestBreadthFirstCycles", func(t *testing.T) {
+		ring := &testLink{Name: "a", Next: &testLink{Name: "b"}}
+		ring.Next.Next = ring
+
+		shared := map[string]any{"name": "shared"}
+		diamond := map[string]any{"left": shared, "right": shared}
+
+		got, err := TraverseString(ring, NoneFilter, WithOrder(BreadthFirst))
+		if err != nil || !EqualSlices(t, []string{"a", "b"}, got) {
+			t.Errorf("TraverseString() = (%v, %v), want [a b]", got, err)
+		}
+
+		got, err = TraverseString(diamond, NoneFilter, WithOrder(BreadthFirst))
+		if err != nil || !EqualSlices(t, []string{"shared", "shared"}, got) {
+			t.Errorf("TraverseString() = (%v, %v), want [shared shared]", got, err)
+		}
+
+		err = Walk(ring, func(Node) bool { return true },
+			WithOrder(BreadthFirst), WithCycleMode(CycleError))
+		if !errors.Is(err, ErrCycle) {
+			t.Errorf("Walk() error = %v, want %v", err, ErrCycle)
+		}
+	})
+
+	t.Run("TestWalkVisitor", func(t *testing.T) {
+		p := &testPrinter{}
+		err := WalkVisitor(tree, p, WithKeyOrder(LexicalOrder), WithOrder(BreadthFirst))
+		want := "{a:[1 {b:x}] c:{City:Oslo Zip:}}"
+		if err != nil || p.String() != want {
+			t.Errorf("WalkVisitor() = (%q, %v), want %q", p.String(), err, want)
+		}
+
+		p = &testPrinter{stopAt: "a"}
+		WalkVisitor(tree, p, WithKeyOrder(LexicalOrder))
+		if want := "{a:[1 {b:x}]"; p.String() != want {
+			t.Errorf("WalkVisitor() = %q, want %q", p.String(), want)
+		}
+
+		if err := WalkVisitor(nil, p); err != ErrNilTree {
+			t.Errorf("WalkVisitor(nil) error = %v, want %v", err, ErrNilTree)
+		}
+	})
+}
+
+// testPrinter prints a tree in a compact form, to exercise Visitor.
+type testPrinter struct {
+	strings.Builder
+	stopAt string
+}
+
+func (p *testPrinter) Enter(n Node) Action {
+	if n.Parent != nil {
+		if p.String()[p.Len()-1] != '{' && p.String()[p.Len()-1] != '[' {
+			p.WriteByte(' ')
+		}
+		if n.Index < 0 {
+			p.WriteString(n.Key + ":")
+		}
+	}
+
+	switch n.Value.Kind() {
+	case reflect.Map, reflect.Struct:
+		p.WriteByte('{')
+	case reflect.Slice:
+		p.WriteByte('[')
+	default:
+		fmt.Fprint(p, n.Value)
+		return SkipChildren
+	}
+	return Continue
+}
+
+func (p *testPrinter) Leave(n Node) Action {
+	switch n.Value.Kind() {
+	case reflect.Map, reflect.Struct:
+		p.WriteByte('}')
+	case reflect.Slice:
+		p.WriteByte(']')
+	}
+	if n.FullKey == p.stopAt {
+		return Stop
+	}
+	return Continue
+}